@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 )
@@ -116,6 +117,38 @@ func (b Bool) Value() (driver.Value, error) {
 	return nil, nil
 }
 
+// Scan reads SQL booleans, 0/1 integers like MySQL's TINYINT(1), and text like Postgres' "t"/"f".
+func (b *Bool) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*b = Bool{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case bool:
+		*b = NewBool(value)
+		return nil
+	case int64:
+		if value != 0 && value != 1 {
+			return fmt.Errorf("meta: cannot scan %d into Bool", value)
+		}
+		*b = NewBool(value == 1)
+		return nil
+	case string:
+		return b.scanString(value)
+	case []byte:
+		return b.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Bool", src)
+}
+
+func (b *Bool) scanString(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*b = NewBool(v)
+	return nil
+}
+
 func (b Bool) MarshalJSON() ([]byte, error) {
 	if b.Present && !b.Null {
 		return MetaJson.Marshal(b.Val)
@@ -0,0 +1,270 @@
+package meta
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//
+// BoolSlice
+//
+
+type BoolSlice struct {
+	Presence
+	Nullity
+	Val  []bool
+	Path string
+	// sqlFormat is how Value stores the slice, "" for SQLFormatArray
+	sqlFormat string
+}
+
+type BoolSliceOptions struct {
+	*BoolOptions
+	*SliceOptions
+}
+
+func (i *BoolSlice) ParseOptions(tag reflect.StructTag) interface{} {
+	var tempB Bool
+	opts := tempB.ParseOptions(tag)
+
+	return &BoolSliceOptions{
+		BoolOptions:  opts.(*BoolOptions),
+		SliceOptions: ParseSliceOptions(tag),
+	}
+}
+
+func (n *BoolSlice) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	opts := options.(*BoolSliceOptions)
+	boolOpts := opts.BoolOptions
+	sliceOpts := opts.SliceOptions
+
+	n.Path = path
+	n.Val = nil
+	n.sqlFormat = sliceOpts.SQLFormat
+	n.Present = true
+	n.Null = false
+
+	if i == nil {
+		n.Null = true
+		if sliceOpts.DiscardBlank {
+			n.Present = false
+			return nil
+		} else if sliceOpts.Null {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	var errorsInSlice ErrorSlice
+	switch value := i.(type) {
+	case string:
+		return n.FormValue(value, options)
+	case []string:
+		return n.formValues(value, options)
+	case []interface{}:
+		n.Val = []bool{}
+
+		if sliceOpts.MinLengthPresent && len(value) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(value) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(value) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+
+		for _, v := range value {
+			var b Bool
+			if err := b.JSONValue("", v, boolOpts); err != nil {
+				errorsInSlice = append(errorsInSlice, err)
+			} else {
+				errorsInSlice = append(errorsInSlice, nil)
+				n.Val = append(n.Val, b.Val)
+			}
+		}
+		if errorsInSlice.Len() > 0 {
+			return errorsInSlice
+		}
+
+		var err Errorable
+		if n.Val, err = constrainValues(n.Val, sliceOpts); err != nil {
+			return err
+		}
+
+		if sliceOpts.MinLengthPresent && len(n.Val) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(n.Val) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(n.Val) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+	}
+	return nil
+}
+
+func (i *BoolSlice) FormValue(value string, options interface{}) Errorable {
+	return i.formValues([]string{value}, options)
+}
+
+// formValues decodes the values of a form key, repeated keys giving several values.
+func (i *BoolSlice) formValues(values []string, options interface{}) Errorable {
+	var tempB Bool
+
+	opts := options.(*BoolSliceOptions)
+	boolOpts := opts.BoolOptions
+	sliceOpts := opts.SliceOptions
+
+	i.Val = []bool{}
+	i.sqlFormat = sliceOpts.SQLFormat
+	i.Present = true
+	i.Null = false
+
+	values = append([]string(nil), values...)
+	blank := true
+	for j, value := range values {
+		values[j] = strings.TrimSpace(value)
+		blank = blank && values[j] == ""
+	}
+
+	if blank {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	strs, err := sliceOpts.split(values)
+	if err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(strs) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(strs) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	var errorsInSlice ErrorSlice
+	for _, s := range strs {
+		tempB.Val = false
+		if err := tempB.FormValue(strings.TrimSpace(s), boolOpts); err != nil {
+			errorsInSlice = append(errorsInSlice, err)
+		} else {
+			errorsInSlice = append(errorsInSlice, nil)
+			i.Val = append(i.Val, tempB.Val)
+		}
+	}
+
+	if errorsInSlice.Len() > 0 {
+		return errorsInSlice
+	}
+
+	if i.Val, err = constrainValues(i.Val, sliceOpts); err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(i.Val) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(i.Val) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	if len(i.Val) == 0 {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	return nil
+}
+
+// Value stores the slice as a Postgres array literal, eg "{t,f}", or as a JSON array when the
+// field's meta_sql_format is "json".
+func (s BoolSlice) Value() (driver.Value, error) {
+	if s.Null || (!s.Present && s.Val == nil) {
+		return nil, nil
+	}
+	values := make([]driver.Value, len(s.Val))
+	for i, v := range s.Val {
+		values[i] = v
+	}
+	return encodeSQLSlice(values, s.sqlFormat)
+}
+
+// WithSQLFormat returns a copy of s that Value stores in format, SQLFormatArray or SQLFormatJSON.
+func (s BoolSlice) WithSQLFormat(format string) BoolSlice {
+	s.sqlFormat = parseSQLFormat(format)
+	return s
+}
+
+// Scan reads Postgres boolean arrays, eg "{t,f}", and JSON arrays of booleans. The SQL format of s
+// is kept.
+func (s *BoolSlice) Scan(src interface{}) error {
+	if src == nil {
+		*s = BoolSlice{Nullity: Nullity{true}, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+		return nil
+	}
+
+	elems, err := ScanSlice[Bool](src)
+	if err != nil {
+		return err
+	}
+	val := make([]bool, len(elems))
+	for i, elem := range elems {
+		if elem.Null {
+			return fmt.Errorf("meta: NULL element %d in BoolSlice", i)
+		}
+		val[i] = elem.Val
+	}
+	*s = BoolSlice{Val: val, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+	return nil
+}
+
+func (s BoolSlice) MarshalJSON() ([]byte, error) {
+	if len(s.Val) > 0 {
+		return MetaJson.Marshal(s.Val)
+	}
+	return nullString, nil
+}
+
+func (s *BoolSlice) UnmarshalJSON(data []byte) error {
+	var value []bool
+	err := MetaJson.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	*s = BoolSlice{Val: value}
+	return nil
+}
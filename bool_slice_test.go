@@ -0,0 +1,58 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type withBoolSlice struct {
+	A BoolSlice
+}
+
+var withBoolSliceDecoder = NewDecoder(&withBoolSlice{})
+
+func TestBoolSliceSuccess(t *testing.T) {
+	var inputs withBoolSlice
+
+	e := withBoolSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"true, false,1"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []bool{true, false, true})
+
+	e = withBoolSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[true,"false"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []bool{true, false})
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestBoolSliceInvalid(t *testing.T) {
+	var inputs withBoolSlice
+
+	e := withBoolSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"true,maybe"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{nil, ErrBool}})
+
+	e = withBoolSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[{},true]}`))
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{ErrBool, nil}})
+}
+
+func TestBoolSliceSQL(t *testing.T) {
+	v, err := BoolSlice{Val: []bool{true, false}}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{true,false}")
+
+	var scanned BoolSlice
+	err = scanned.Scan([]byte("{t,f}"))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []bool{true, false})
+
+	err = scanned.Scan("[false,true]")
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []bool{false, true})
+
+	err = scanned.Scan("{t,NULL}")
+	assert(t, err != nil)
+
+	bs, err := json.Marshal(scanned)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "[false,true]")
+}
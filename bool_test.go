@@ -224,3 +224,29 @@ func TestOptionalNullBoolOmitted(t *testing.T) {
 	assertEqual(t, inputs.A.Null, false)
 	assertEqual(t, inputs.A.Val, false)
 }
+
+func TestBoolScan(t *testing.T) {
+	var b Bool
+
+	err := b.Scan(true)
+	assertEqual(t, err, nil)
+	assertEqual(t, b, NewBool(true))
+
+	err = b.Scan([]byte("f"))
+	assertEqual(t, err, nil)
+	assertEqual(t, b, NewBool(false))
+
+	err = b.Scan(int64(1))
+	assertEqual(t, err, nil)
+	assertEqual(t, b.Val, true)
+
+	err = b.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, b, Bool{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = b.Scan(int64(2))
+	assert(t, err != nil)
+
+	err = b.Scan("maybe")
+	assert(t, err != nil)
+}
@@ -0,0 +1,30 @@
+package meta
+
+import "time"
+
+// Clock provides the current time to time expressions ("now", "3_days_ago", ...) and to
+// relative meta_min/meta_max limits. Set it in DecoderOptions to make decoding deterministic
+// in tests, or to evaluate input as of a historical point.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// FixedClock returns a Clock that always reports t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// clockNow returns the time from clock, or time.Now when clock is nil.
+func clockNow(clock Clock) time.Time {
+	if clock != nil {
+		return clock.Now()
+	}
+	return time.Now()
+}
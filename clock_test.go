@@ -0,0 +1,75 @@
+package meta
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClockTimeExpressions(t *testing.T) {
+	asOf := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	var inputs struct {
+		A Time
+		B Time `meta_min:"3_days_ago" meta_max:"now"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(asOf)})
+
+	for expression, expected := range map[string]time.Time{
+		"now":              asOf,
+		"today":            time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		"yesterday":        time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC),
+		"tomorrow":         time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		"3_days_ago":       time.Date(2024, 3, 7, 14, 30, 0, 0, time.UTC),
+		"2_hours_from_now": time.Date(2024, 3, 10, 16, 30, 0, 0, time.UTC),
+	} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {expression}})
+		assertEqual(t, e, ErrorHash(nil), expression)
+		assertEqual(t, inputs.A.Val, expected, expression)
+	}
+
+	e := d.DecodeValues(&inputs, url.Values{"b": {"2024-03-07T14:30:00Z"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"b": {"2024-03-07T14:29:00Z"}})
+	assertEqual(t, e, ErrorHash{"b": ErrMin})
+
+	e = d.DecodeValues(&inputs, url.Values{"b": {"2024-03-10T14:31:00Z"}})
+	assertEqual(t, e, ErrorHash{"b": ErrMax})
+}
+
+func TestClockFuncMidnight(t *testing.T) {
+	// one nanosecond before midnight and at midnight: "today" must follow the clock, not the wall time
+	current := time.Date(2024, 3, 10, 23, 59, 59, 999999999, time.UTC)
+	clock := ClockFunc(func() time.Time { return current })
+
+	var inputs struct {
+		A Time `meta_max:"today"`
+		B Date `meta_min:"today"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: clock})
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"2024-03-10T00:00:00Z"}, "b": {"2024-03-10"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	current = current.Add(time.Nanosecond)
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2024-03-11T00:00:00Z"}, "b": {"2024-03-10"}})
+	assertEqual(t, e, ErrorHash{"b": ErrMin})
+
+	e = d.DecodeValues(&inputs, url.Values{"b": {"today"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.B.Val, CivilDate{2024, time.March, 11})
+}
+
+func TestClockWithLocation(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	var inputs struct {
+		A Time `meta_location:"Asia/Tokyo"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC))})
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"today"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, tokyo)))
+}
@@ -0,0 +1,456 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Core Types and Structures
+//
+
+// CronSchedule is a parsed cron expression. Each field is a bit set of the values it matches.
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domAny and dowAny record a "*" or "?" day field. When both day fields are restricted
+	// a day matches either of them, like in Vixie cron.
+	domAny, dowAny bool
+	expr           string
+}
+
+type Cron struct {
+	Val CronSchedule
+	Nullity
+	Presence
+	Path string
+}
+
+type CronOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// MinInterval is the shortest allowed time between two runs of the schedule.
+	// Configured via meta_min_interval tag, written like Duration input.
+	// Default: 0 (no limit)
+	// Examples: `meta_min_interval:"15m"`, `meta_min_interval:"1h"`
+	MinInterval time.Duration
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSeconds = cronField{0, 59, nil}
+	cronMinutes = cronField{0, 59, nil}
+	cronHours   = cronField{0, 23, nil}
+	cronDom     = cronField{1, 31, nil}
+	cronMonths  = cronField{1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted for Sunday and folded onto 0
+	cronDow = cronField{0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronMacros maps the supported macros to their five-field expression.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+const (
+	// cronSearchYears bounds Next for schedules that rarely or never match, eg "0 0 30 2 *".
+	cronSearchYears = 5
+)
+
+//
+// Constructors
+//
+
+func NewCron(schedule CronSchedule) Cron {
+	return Cron{schedule, Nullity{false}, Presence{true}, ""}
+}
+
+// ParseCronSchedule parses a five-field ("min hour dom month dow") or six-field, with seconds first,
+// cron expression, or a macro like "@daily". Fields accept "*" (or "?"), values,
+// month and weekday names, ranges "a-b", lists "a,b" and steps "*/n", "a-b/n" or "a/n".
+func ParseCronSchedule(expr string) (CronSchedule, error) {
+	fields := strings.Fields(strings.ToLower(expr))
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := cronMacros[fields[0]]
+		if !ok {
+			return CronSchedule{}, fmt.Errorf("meta: unknown cron macro %q", fields[0])
+		}
+		schedule, err := ParseCronSchedule(macro)
+		schedule.expr = fields[0]
+		return schedule, err
+	}
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return CronSchedule{}, fmt.Errorf("meta: expected 5 or 6 cron fields, got %d", len(fields))
+	}
+
+	var s CronSchedule
+	var err error
+	specs := []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&s.second, cronSeconds},
+		{&s.minute, cronMinutes},
+		{&s.hour, cronHours},
+		{&s.dom, cronDom},
+		{&s.month, cronMonths},
+		{&s.dow, cronDow},
+	}
+	for i, spec := range specs {
+		if *spec.bits, err = spec.field.parse(fields[i]); err != nil {
+			return CronSchedule{}, err
+		}
+	}
+
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = fields[3] == "*" || fields[3] == "?"
+	s.dowAny = fields[5] == "*" || fields[5] == "?"
+	s.expr = strings.Join(strings.Fields(expr), " ")
+
+	if s.Next(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return CronSchedule{}, fmt.Errorf("meta: cron expression %q never matches", expr)
+	}
+	return s, nil
+}
+
+//
+// CronSchedule Methods
+//
+
+// String returns the expression the schedule was parsed from, with whitespace normalized.
+func (s CronSchedule) String() string {
+	return s.expr
+}
+
+func (s CronSchedule) IsZero() bool {
+	return s.expr == ""
+}
+
+// Next returns the first time strictly after after matching the schedule, in after's location,
+// or the zero time when there is none within cronSearchYears.
+func (s CronSchedule) Next(after time.Time) time.Time {
+	if s.IsZero() {
+		return time.Time{}
+	}
+
+	loc := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = advanceTo(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = advanceTo(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = advanceTo(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(time.Hour))
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// advanceTo returns next, or an hour after t when a DST change made time.Date normalize next
+// to t or earlier.
+func advanceTo(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Hour)
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if !s.domAny && !s.dowAny {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// minInterval returns the shortest time between consecutive runs. Runs happen at the same times on
+// every matching day, so it is the shortest gap within a day, or between the last run of a day and
+// the first run of the next matching day.
+func (s CronSchedule) minInterval() time.Duration {
+	shortest := time.Duration(math.MaxInt64)
+	first, last := -1, -1
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if s.minute&(1<<uint(m)) == 0 {
+				continue
+			}
+			for sec := 0; sec < 60; sec++ {
+				if s.second&(1<<uint(sec)) == 0 {
+					continue
+				}
+				at := h*3600 + m*60 + sec
+				if first < 0 {
+					first = at
+				} else if gap := time.Duration(at-last) * time.Second; gap < shortest {
+					shortest = gap
+				}
+				last = at
+			}
+		}
+	}
+
+	if days := s.minDayGap(); first >= 0 && days > 0 {
+		if gap := time.Duration(days*86400-last+first) * time.Second; gap < shortest {
+			shortest = gap
+		}
+	}
+	return shortest
+}
+
+// minDayGap returns the fewest days between two consecutive matching days over eight years, which
+// covers every leap year transition, or 0 when there are no two matching days.
+func (s CronSchedule) minDayGap() int {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(8, 0, 0)
+
+	shortest, prev := 0, -1
+	for i, t := 0, start; t.Before(end); i, t = i+1, t.AddDate(0, 0, 1) {
+		if s.month&(1<<uint(t.Month())) == 0 || !s.matchesDay(t) {
+			continue
+		}
+		if prev >= 0 && (shortest == 0 || i-prev < shortest) {
+			shortest = i - prev
+			if shortest == 1 {
+				break
+			}
+		}
+		prev = i
+	}
+	return shortest
+}
+
+//
+// Field Parsing
+//
+
+// parse returns the bit set of the values matched by a comma-separated list of field terms.
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, term := range strings.Split(value, ",") {
+		b, err := f.parseTerm(term)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+func (f cronField) parseTerm(term string) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(term, "/")
+
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepPart)
+		if err != nil || n < 1 || n > f.max {
+			return 0, fmt.Errorf("meta: invalid cron step %q", term)
+		}
+		step = n
+	}
+
+	var low, high int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		low, high = f.min, f.max
+	case strings.Contains(rangePart, "-"):
+		lowPart, highPart, _ := strings.Cut(rangePart, "-")
+		var err error
+		if low, err = f.parseValue(lowPart); err != nil {
+			return 0, err
+		}
+		if high, err = f.parseValue(highPart); err != nil {
+			return 0, err
+		}
+		if high < low {
+			return 0, fmt.Errorf("meta: invalid cron range %q", term)
+		}
+	default:
+		var err error
+		if low, err = f.parseValue(rangePart); err != nil {
+			return 0, err
+		}
+		high = low
+		if hasStep {
+			high = f.max
+		}
+	}
+
+	var bits uint64
+	for v := low; v <= high; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func (f cronField) parseValue(value string) (int, error) {
+	if n, ok := f.names[value]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("meta: invalid cron value %q", value)
+	}
+	return n, nil
+}
+
+//
+// Core Cron Methods
+//
+
+func (c *Cron) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &CronOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+	}
+
+	if interval := tag.Get("meta_min_interval"); interval != "" {
+		d, ok := parseDuration(interval)
+		if !ok || d <= 0 {
+			panic("invalid meta_min_interval " + interval)
+		}
+		opts.MinInterval = d
+	}
+
+	return opts
+}
+
+func (c *Cron) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	c.Path = path
+	if i == nil {
+		return c.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return c.FormValue(value, options)
+	case CronSchedule:
+		return c.FormValue(value.String(), options)
+	}
+	return ErrCron
+}
+
+func (c *Cron) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*CronOptions)
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if opts.Null {
+			c.Present = true
+			c.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			c.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	schedule, err := ParseCronSchedule(value)
+	if err != nil {
+		return ErrCron
+	}
+
+	if opts.MinInterval > 0 && schedule.minInterval() < opts.MinInterval {
+		return ErrCronInterval
+	}
+
+	c.Val = schedule
+	c.Present = true
+	return nil
+}
+
+// Next returns the first run of the schedule strictly after after, see CronSchedule.Next.
+func (c Cron) Next(after time.Time) time.Time {
+	return c.Val.Next(after)
+}
+
+func (c Cron) Value() (driver.Value, error) {
+	if c.Present && !c.Null {
+		return c.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (c Cron) MarshalJSON() ([]byte, error) {
+	if c.Present && !c.Null {
+		return MetaJson.Marshal(c.Val.String())
+	}
+	return nullString, nil
+}
+
+func (c *Cron) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		c.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := ParseCronSchedule(s)
+	if err != nil {
+		return err
+	}
+
+	c.Val = schedule
+	c.Presence = Presence{true}
+	c.Nullity = Nullity{false}
+	return nil
+}
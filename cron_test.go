@@ -0,0 +1,186 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withCron struct {
+	A Cron `meta_required:"true"`
+}
+
+var withCronDecoder = NewDecoder(&withCron{})
+
+func TestCronSuccess(t *testing.T) {
+	for _, input := range []string{
+		"* * * * *",
+		"*/15 9-17 * * mon-fri",
+		"0 0 1,15 * *",
+		"30 6 * JAN,JUL SUN",
+		"0 12 ? * 7",
+		"5/10 * * * *",
+		"0 0 29 2 *",
+		"*/30 * * * * *",
+		"@daily",
+		"@Weekly",
+	} {
+		var inputs withCron
+		e := withCronDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Present, true, input)
+	}
+
+	var inputs withCron
+	e := withCronDecoder.DecodeJSON(&inputs, []byte(`{"a":"  0   9 * * 1 "}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val.String(), "0 9 * * 1")
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestCronInvalid(t *testing.T) {
+	var inputs withCron
+
+	for _, bad := range []string{
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"0 0 30 2 *",
+		"0 0 L * *",
+		"@fortnightly",
+	} {
+		e := withCronDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrCron}, bad)
+	}
+
+	e := withCronDecoder.DecodeJSON(&inputs, []byte(`{"a":5}`))
+	assertEqual(t, e, ErrorHash{"a": ErrCron})
+
+	e = withCronDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withCronDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestCronMinInterval(t *testing.T) {
+	var inputs struct {
+		A Cron `meta_min_interval:"15m"`
+	}
+	d := NewDecoder(&inputs)
+
+	for _, ok := range []string{"*/15 * * * *", "0,20,40 * * * *", "@hourly", "0 0 1,31 * *"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {ok}})
+		assertEqual(t, e, ErrorHash(nil), ok)
+	}
+
+	for _, bad := range []string{"* * * * *", "*/5 * * * *", "0,10 * * * *", "*/30 * * * * *", "50 8 * * *,0 9 * * *"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assert(t, e != nil, bad)
+	}
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"55 * * * *"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"0,55 * * * *"}})
+	assertEqual(t, e, ErrorHash{"a": ErrCronInterval})
+
+	for expr, expected := range map[string]time.Duration{
+		"* * * * *":           time.Minute,
+		"*/10 * * * * *":      10 * time.Second,
+		"0 0,23 * * *":        time.Hour,
+		"30 9,17 * * mon-fri": 8 * time.Hour,
+		"0 0 * * sat,mon":     48 * time.Hour,
+		"0 0 1 * *":           28 * 24 * time.Hour,
+		"0 0 29 2 *":          1461 * 24 * time.Hour,
+		"0 0 31 1,3 *":        59 * 24 * time.Hour,
+	} {
+		c, err := ParseCronSchedule(expr)
+		assertEqual(t, err, nil, expr)
+		assertEqual(t, c.minInterval(), expected, expr)
+	}
+}
+
+func BenchmarkCronMinInterval(b *testing.B) {
+	var inputs struct {
+		A Cron `meta_min_interval:"1m"`
+	}
+	d := NewDecoder(&inputs)
+
+	for i := 0; i < b.N; i++ {
+		if e := d.DecodeValues(&inputs, url.Values{"a": {"* * * * *"}}); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	after := time.Date(2024, 3, 13, 14, 30, 15, 0, time.UTC)
+
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":       time.Date(2024, 3, 13, 14, 31, 0, 0, time.UTC),
+		"*/10 * * * * *":  time.Date(2024, 3, 13, 14, 30, 20, 0, time.UTC),
+		"0 9 * * mon-fri": time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC),
+		"0 9 * * sat":     time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC),
+		"@monthly":        time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		"@yearly":         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 31 * *":      time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":      time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * fri":     time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		"30 14 13 3 *":    time.Date(2025, 3, 13, 14, 30, 0, 0, time.UTC),
+		"15 30 14 13 3 *": time.Date(2025, 3, 13, 14, 30, 15, 0, time.UTC),
+		"0 0 * * 7":       time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC),
+		"0 12 1-7 * ?":    time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+		"0 0 1 jan,jul *": time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		"0 0-23/6 * * *":  time.Date(2024, 3, 13, 18, 0, 0, 0, time.UTC),
+		"0 0 * * 1-5/2":   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 13 * *":      time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC),
+	} {
+		c, err := ParseCronSchedule(expr)
+		assertEqual(t, err, nil, expr)
+		next := NewCron(c).Next(after)
+		assert(t, next.Equal(expected), "%s: expected %s, got %s", expr, expected, next)
+	}
+
+	ny, _ := time.LoadLocation("America/New_York")
+	c, _ := ParseCronSchedule("30 2 * * *")
+	next := c.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	assert(t, next.Equal(time.Date(2024, 3, 11, 2, 30, 0, 0, ny)), "skips the missing 2:30 on DST day, got %s", next)
+
+	c, _ = ParseCronSchedule("0 * * * *")
+	fallBack := time.Date(2024, 11, 3, 1, 30, 0, 0, ny)
+	next = c.Next(fallBack)
+	assertEqual(t, next.Sub(fallBack), 30*time.Minute)
+
+	assert(t, CronSchedule{}.Next(after).IsZero())
+}
+
+func TestCronJSON(t *testing.T) {
+	s, _ := ParseCronSchedule("@daily")
+	c := NewCron(s)
+
+	bs, err := json.Marshal(c)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"@daily"`)
+
+	var decoded Cron
+	err = json.Unmarshal([]byte(`"0 */2 * * *"`), &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val.String(), "0 */2 * * *")
+	assertEqual(t, decoded.Present, true)
+
+	err = json.Unmarshal([]byte(`"every day"`), &decoded)
+	assert(t, err != nil)
+
+	v, err := c.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "@daily")
+}
@@ -0,0 +1,343 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+//
+// Core Types and Structures
+//
+
+// CivilDate is a calendar date without a time of day or time zone.
+type CivilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+type Date struct {
+	Val CivilDate
+	Nullity
+	Presence
+	Path string
+}
+
+type DateOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// Format specifies the layouts used to parse date strings, the same way as TimeOptions.Format.
+	// Layouts that include a time or zone are accepted and the date is taken as written.
+	// Configured via meta_format tag.
+	// Default: [DateOnly, "expression"]
+	// Examples: `meta_format:"DateOnly"`, `meta_format:"DateOnly,1/2/2006"`
+	Format []string
+	// MinDate sets the minimum allowed date.
+	// Configured via meta_min tag. Accepts a date in one of Format or a relative expression
+	// like "today" or "3_days_ago", and supports exclusive boundaries with "!" prefix.
+	// Examples: `meta_min:"2024-01-01"`, `meta_min:"today"`, `meta_min:"!30_days_ago"`
+	MinDate *civilDateLimit
+	// MaxDate sets the maximum allowed date, configured via meta_max tag like MinDate.
+	MaxDate *civilDateLimit
+	// Clock provides the current date for expressions and relative limits.
+	// Configured via DecoderOptions.Clock.
+	// Default: nil (time.Now)
+	Clock Clock
+}
+
+type civilDateLimit struct {
+	value      CivilDate
+	isAbsolute bool
+	exclusive  bool
+	raw        string
+}
+
+//
+// Constructors
+//
+
+func NewCivilDate(year int, month time.Month, day int) CivilDate {
+	return CivilDateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// CivilDateOf returns the date of t in t's location.
+func CivilDateOf(t time.Time) CivilDate {
+	year, month, day := t.Date()
+	return CivilDate{year, month, day}
+}
+
+func NewDate(d CivilDate) Date {
+	return Date{d, Nullity{false}, Presence{true}, ""}
+}
+
+//
+// CivilDate Methods
+//
+
+// String returns the date in time.DateOnly format.
+func (d CivilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d CivilDate) IsZero() bool {
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// In returns midnight at the start of the date in loc.
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d CivilDate) AddDays(n int) CivilDate {
+	return CivilDateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or after other.
+func (d CivilDate) Compare(other CivilDate) int {
+	switch {
+	case d.Year != other.Year:
+		return compareInts(d.Year, other.Year)
+	case d.Month != other.Month:
+		return compareInts(int(d.Month), int(other.Month))
+	default:
+		return compareInts(d.Day, other.Day)
+	}
+}
+
+func (d CivilDate) Before(other CivilDate) bool {
+	return d.Compare(other) < 0
+}
+
+func (d CivilDate) After(other CivilDate) bool {
+	return d.Compare(other) > 0
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+//
+// Core Date Methods
+//
+
+func (d *Date) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &DateOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		Format:       []string{time.DateOnly, "expression"},
+	}
+
+	if formatTag := tag.Get("meta_format"); formatTag != "" {
+		opts.Format = parseFormats(formatTag)
+	}
+
+	opts.MinDate = newCivilDateLimit(tag.Get("meta_min"), opts)
+	opts.MaxDate = newCivilDateLimit(tag.Get("meta_max"), opts)
+
+	return opts
+}
+
+func (d *Date) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	d.Path = path
+	if i == nil {
+		return d.FormValue("", options)
+	}
+
+	opts := options.(*DateOptions)
+
+	switch value := i.(type) {
+	case CivilDate:
+		if value.IsZero() {
+			return d.handleEmptyValue(opts)
+		}
+		return d.validateValue(value, opts)
+	case time.Time:
+		if value.IsZero() {
+			return d.handleEmptyValue(opts)
+		}
+		return d.validateValue(CivilDateOf(value), opts)
+	case string:
+		return d.FormValue(value, options)
+	}
+
+	return ErrDate
+}
+
+func (d *Date) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*DateOptions)
+
+	if value == "" {
+		return d.handleEmptyValue(opts)
+	}
+
+	v, ok := parseCivilDate(value, opts)
+	if !ok {
+		return ErrDate
+	}
+	return d.validateValue(v, opts)
+}
+
+// Value stores the date as a DateOnly string, which SQL DATE columns accept without a zone conversion.
+func (d Date) Value() (driver.Value, error) {
+	if d.Present && !d.Null {
+		return d.Val.String(), nil
+	}
+	return nil, nil
+}
+
+// Scan reads SQL DATE values. Drivers return them either as time.Time, whose date is taken
+// as-is in its own location, or as text.
+func (d *Date) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*d = Date{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case time.Time:
+		*d = NewDate(CivilDateOf(value))
+		return nil
+	case string:
+		return d.scanString(value)
+	case []byte:
+		return d.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Date", src)
+}
+
+func (d *Date) scanString(value string) error {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return err
+	}
+	*d = NewDate(CivilDateOf(t))
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.Present && !d.Null {
+		return MetaJson.Marshal(d.Val.String())
+	}
+	return nullString, nil
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		d.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return err
+	}
+
+	d.Val = CivilDateOf(t)
+	d.Presence = Presence{true}
+	d.Nullity = Nullity{false}
+	return nil
+}
+
+//
+// Value Handling Methods
+//
+
+func (d *Date) handleEmptyValue(opts *DateOptions) Errorable {
+	if opts.Null {
+		d.Present = true
+		d.Null = true
+		return nil
+	}
+	if opts.Required {
+		return ErrBlank
+	}
+	if !opts.DiscardBlank {
+		d.Present = true
+		return ErrBlank
+	}
+	return nil
+}
+
+func (d *Date) validateValue(value CivilDate, opts *DateOptions) Errorable {
+	if opts.MinDate != nil {
+		cmp := value.Compare(opts.MinDate.Value(opts))
+		if cmp < 0 || (cmp == 0 && opts.MinDate.exclusive) {
+			return ErrMin
+		}
+	}
+	if opts.MaxDate != nil {
+		cmp := value.Compare(opts.MaxDate.Value(opts))
+		if cmp > 0 || (cmp == 0 && opts.MaxDate.exclusive) {
+			return ErrMax
+		}
+	}
+
+	d.Val = value
+	d.Present = true
+	return nil
+}
+
+func parseCivilDate(value string, opts *DateOptions) (CivilDate, bool) {
+	for _, format := range opts.Format {
+		switch format {
+		case "expression":
+			if v := resolveTimeExpression(value, clockNow(opts.Clock)); v != nil {
+				return CivilDateOf(*v), true
+			}
+		default:
+			if v, err := time.Parse(format, value); err == nil {
+				return CivilDateOf(v), true
+			}
+		}
+	}
+	return CivilDate{}, false
+}
+
+//
+// Date Limit Methods
+//
+
+func newCivilDateLimit(raw string, opts *DateOptions) *civilDateLimit {
+	if raw == "" {
+		return nil
+	}
+
+	value, exclusive := parseExclusivePrefix(raw)
+
+	if v := resolveTimeExpression(value, clockNow(opts.Clock)); v != nil {
+		return &civilDateLimit{raw: value, exclusive: exclusive}
+	}
+
+	v, ok := parseCivilDate(value, opts)
+	if !ok {
+		panic("invalid date limit " + raw)
+	}
+
+	return &civilDateLimit{value: v, isAbsolute: true, exclusive: exclusive, raw: value}
+}
+
+func (l *civilDateLimit) Value(opts *DateOptions) CivilDate {
+	if l.isAbsolute {
+		return l.value
+	}
+
+	now := clockNow(opts.Clock)
+	if v := resolveTimeExpression(l.raw, now); v != nil {
+		return CivilDateOf(*v)
+	}
+	return CivilDateOf(now)
+}
@@ -0,0 +1,194 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withDate struct {
+	A Date `meta_required:"true"`
+}
+
+var withDateDecoder = NewDecoder(&withDate{})
+
+func TestDateSuccess(t *testing.T) {
+	var inputs withDate
+
+	e := withDateDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-02-29"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, CivilDate{2024, time.February, 29})
+	assertEqual(t, inputs.A.Present, true)
+
+	inputs = withDate{}
+	e = withDateDecoder.DecodeJSON(&inputs, []byte(`{"a":"2015-06-02"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, CivilDate{2015, time.June, 2})
+	assertEqual(t, inputs.A.Path, "a")
+
+	e = withDateDecoder.DecodeMap(&inputs, map[string]interface{}{"a": time.Date(2020, 1, 31, 23, 0, 0, 0, time.FixedZone("", -5*3600))})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, CivilDate{2020, time.January, 31})
+}
+
+func TestDateInvalid(t *testing.T) {
+	var inputs withDate
+
+	for _, bad := range []string{"2024-02-30", "2024-13-01", "02/01/2024", "2024-02-01T00:00:00Z", "soon"} {
+		e := withDateDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrDate}, bad)
+	}
+
+	e := withDateDecoder.DecodeJSON(&inputs, []byte(`{"a":20240101}`))
+	assertEqual(t, e, ErrorHash{"a": ErrDate})
+
+	e = withDateDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withDateDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestDateCustomFormat(t *testing.T) {
+	var inputs struct {
+		A Date `meta_format:"1/2/2006,RFC3339"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"9/1/2015"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, CivilDate{2015, time.September, 1})
+
+	// the date is taken as written, regardless of the offset
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2015-09-01T23:30:00-07:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, CivilDate{2015, time.September, 1})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"today"}})
+	assertEqual(t, e, ErrorHash{"a": ErrDate})
+}
+
+func TestDateExpressions(t *testing.T) {
+	var inputs withDate
+	today := CivilDateOf(time.Now())
+
+	e := withDateDecoder.DecodeValues(&inputs, url.Values{"a": {"today"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, today)
+
+	e = withDateDecoder.DecodeValues(&inputs, url.Values{"a": {"yesterday"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, today.AddDays(-1))
+
+	e = withDateDecoder.DecodeValues(&inputs, url.Values{"a": {"3_days_ago"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, today.AddDays(-3))
+}
+
+func TestDateRange(t *testing.T) {
+	var absoluteInputs struct {
+		A Date `meta_min:"2016-01-01" meta_max:"!2016-02-01"`
+	}
+	var relativeInputs struct {
+		A Date `meta_min:"3_days_ago" meta_max:"today"`
+	}
+
+	d := NewDecoder(&absoluteInputs)
+	e := d.DecodeValues(&absoluteInputs, url.Values{"a": {"2016-01-01"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&absoluteInputs, url.Values{"a": {"2016-01-31"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&absoluteInputs, url.Values{"a": {"2015-12-31"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin})
+
+	e = d.DecodeValues(&absoluteInputs, url.Values{"a": {"2016-02-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+
+	d = NewDecoder(&relativeInputs)
+	today := CivilDateOf(time.Now())
+
+	e = d.DecodeValues(&relativeInputs, url.Values{"a": {today.String()}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&relativeInputs, url.Values{"a": {today.AddDays(-3).String()}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&relativeInputs, url.Values{"a": {today.AddDays(-4).String()}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin})
+
+	e = d.DecodeValues(&relativeInputs, url.Values{"a": {"tomorrow"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+}
+
+func TestDateNull(t *testing.T) {
+	var inputs struct {
+		A Date `meta_null:"true"`
+	}
+
+	e := NewDecoder(&inputs).DecodeJSON(&inputs, []byte(`{"a":null}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.A.Null, true)
+}
+
+func TestCivilDate(t *testing.T) {
+	d := NewCivilDate(2024, time.January, 31)
+	assertEqual(t, d.String(), "2024-01-31")
+	assertEqual(t, d.AddDays(1), CivilDate{2024, time.February, 1})
+	assertEqual(t, NewCivilDate(2023, time.February, 29), CivilDate{2023, time.March, 1})
+	assert(t, d.Before(d.AddDays(1)))
+	assert(t, d.After(d.AddDays(-365)))
+	assertEqual(t, d.Compare(d), 0)
+
+	ny, _ := time.LoadLocation("America/New_York")
+	assert(t, d.In(ny).Equal(time.Date(2024, 1, 31, 5, 0, 0, 0, time.UTC)))
+	assert(t, CivilDate{}.IsZero())
+}
+
+func TestDateSQL(t *testing.T) {
+	d := NewDate(NewCivilDate(2024, time.March, 5))
+
+	v, err := d.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "2024-03-05")
+
+	var scanned Date
+	err = scanned.Scan(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned, NewDate(CivilDate{2024, time.March, 5}))
+
+	err = scanned.Scan([]byte("2024-03-06"))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, CivilDate{2024, time.March, 6})
+
+	err = scanned.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Null, true)
+
+	v, err = scanned.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, nil)
+
+	err = scanned.Scan(12)
+	assert(t, err != nil)
+}
+
+func TestDateJSON(t *testing.T) {
+	d := NewDate(NewCivilDate(2024, time.March, 5))
+
+	bs, err := json.Marshal(d)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"2024-03-05"`)
+
+	var decoded Date
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded, d)
+
+	bs, err = json.Marshal(Date{})
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "null")
+}
@@ -0,0 +1,288 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Duration
+//
+
+// Duration is a time.Duration read from Go durations ("1h30m"), ISO 8601 durations ("PT1H30M") or
+// numbers of meta_unit. Value stores it as an integer number of nanoseconds, like time.Duration, so
+// its column must be a bigint rather than an interval.
+type Duration struct {
+	Val time.Duration
+	Nullity
+	Presence
+	Path string
+}
+
+type DurationOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// MinPresent/Min and MaxPresent/Max bound the value.
+	// Configured via meta_min and meta_max tags, written like any accepted input, eg `meta_max:"24h"` or `meta_min:"PT1M"`.
+	MinPresent bool
+	Min        time.Duration
+	MaxPresent bool
+	Max        time.Duration
+	// Unit is the unit of bare numbers, eg 90 or "90".
+	// Configured via meta_unit tag with a Go duration unit: ns, us, ms, s, m, h. Also d for days.
+	// Default: time.Second
+	Unit time.Duration
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+var (
+	// iso8601DurationRegex matches the week/day/time subset of ISO 8601 durations.
+	// Years and months are rejected because their length depends on the start date.
+	iso8601DurationRegex = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	// dayDurationRegex matches Go durations prefixed with week or day components, eg "3d" or "1w2d12h".
+	dayDurationRegex = regexp.MustCompile(`^(-)?(?:(\d+)w)?(?:(\d+)d)?(.*)$`)
+)
+
+func NewDuration(d time.Duration) Duration {
+	return Duration{d, Nullity{false}, Presence{true}, ""}
+}
+
+func (d *Duration) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &DurationOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		Unit:         time.Second,
+	}
+
+	if unit := tag.Get("meta_unit"); unit != "" {
+		u, ok := durationUnits[unit]
+		if !ok {
+			panic("unknown meta_unit " + unit)
+		}
+		opts.Unit = u
+	}
+
+	if s := tag.Get("meta_min"); s != "" {
+		v, ok := parseDuration(s)
+		if !ok {
+			panic("invalid meta_min duration " + s)
+		}
+
+		opts.MinPresent = true
+		opts.Min = v
+	}
+
+	if s := tag.Get("meta_max"); s != "" {
+		v, ok := parseDuration(s)
+		if !ok {
+			panic("invalid meta_max duration " + s)
+		}
+
+		opts.MaxPresent = true
+		opts.Max = v
+	}
+
+	return opts
+}
+
+func (d *Duration) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	d.Path = path
+	if i == nil {
+		return d.FormValue("", options)
+	}
+
+	opts := options.(*DurationOptions)
+
+	switch value := i.(type) {
+	case string:
+		return d.FormValue(value, options)
+	case json.Number:
+		return d.numberValue(string(value), opts)
+	case int:
+		return d.numberValue(strconv.Itoa(value), opts)
+	case int64:
+		return d.numberValue(strconv.FormatInt(value, 10), opts)
+	case float64:
+		return d.numberValue(strconv.FormatFloat(value, 'f', -1, 64), opts)
+	case time.Duration:
+		return d.validateValue(value, opts)
+	}
+	return ErrDuration
+}
+
+func (d *Duration) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*DurationOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			d.Present = true
+			d.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			d.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	if v, ok := parseDuration(value); ok {
+		return d.validateValue(v, opts)
+	}
+	return d.numberValue(value, opts)
+}
+
+// numberValue interprets a bare number in units of opts.Unit.
+func (d *Duration) numberValue(value string, opts *DurationOptions) Errorable {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return ErrDuration
+	}
+
+	f := n * float64(opts.Unit)
+	// float64(math.MaxInt64) rounds up to 2^63, which doesn't fit
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return ErrDurationRange
+	}
+	return d.validateValue(time.Duration(f), opts)
+}
+
+func (d *Duration) validateValue(value time.Duration, opts *DurationOptions) Errorable {
+	if opts.MinPresent && value < opts.Min {
+		return ErrMin
+	}
+	if opts.MaxPresent && value > opts.Max {
+		return ErrMax
+	}
+
+	d.Val = value
+	d.Present = true
+	return nil
+}
+
+func (d Duration) Value() (driver.Value, error) {
+	if d.Present && !d.Null {
+		return int64(d.Val), nil
+	}
+	return nil, nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if d.Present && !d.Null {
+		return MetaJson.Marshal(d.Val.String())
+	}
+	return nullString, nil
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		d.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	if err := MetaJson.Unmarshal(b, &s); err == nil {
+		v, ok := parseDuration(s)
+		if !ok {
+			return ErrDuration
+		}
+		d.Val = v
+	} else if err := MetaJson.Unmarshal(b, &d.Val); err != nil {
+		return err
+	}
+
+	d.Presence = Presence{true}
+	d.Nullity = Nullity{false}
+	return nil
+}
+
+// parseDuration accepts Go durations ("1h30m"), Go durations with leading week and day
+// components ("1w2d", "3d12h") and ISO 8601 durations ("PT1H30M", "P3D").
+func parseDuration(value string) (time.Duration, bool) {
+	if v, err := time.ParseDuration(value); err == nil {
+		return v, true
+	}
+
+	if strings.HasPrefix(value, "P") || strings.HasPrefix(value, "-P") {
+		return parseISO8601Duration(value)
+	}
+
+	m := dayDurationRegex.FindStringSubmatch(value)
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return 0, false
+	}
+
+	const maxDays = math.MaxInt64 / int64(24*time.Hour)
+	weeks, errWeeks := strconv.ParseInt("0"+m[2], 10, 64)
+	days, errDays := strconv.ParseInt("0"+m[3], 10, 64)
+	if errWeeks != nil || errDays != nil || weeks > maxDays/7 || days > maxDays-weeks*7 {
+		return 0, false
+	}
+	total := time.Duration(weeks*7+days) * 24 * time.Hour
+
+	if m[4] != "" {
+		rest, err := time.ParseDuration(m[4])
+		if err != nil || rest < 0 || rest > math.MaxInt64-total {
+			return 0, false
+		}
+		total += rest
+	}
+
+	if m[1] == "-" {
+		total = -total
+	}
+	return total, true
+}
+
+func parseISO8601Duration(value string) (time.Duration, bool) {
+	m := iso8601DurationRegex.FindStringSubmatch(value)
+	if m == nil || value == "P" || value == "-P" || strings.HasSuffix(value, "T") {
+		return 0, false
+	}
+
+	var total float64
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		part := m[i+2]
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total += n * float64(unit)
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which doesn't fit
+	if total >= math.MaxInt64 {
+		return 0, false
+	}
+	if m[1] == "-" {
+		total = -total
+	}
+	return time.Duration(total), true
+}
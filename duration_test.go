@@ -0,0 +1,171 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withDuration struct {
+	A Duration `meta_required:"true"`
+}
+
+var withDurationDecoder = NewDecoder(&withDuration{})
+
+func TestDurationSuccess(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"1h30m":      90 * time.Minute,
+		"250ms":      250 * time.Millisecond,
+		"-5m":        -5 * time.Minute,
+		"PT1H30M":    90 * time.Minute,
+		"P3D":        72 * time.Hour,
+		"P1W":        7 * 24 * time.Hour,
+		"P1DT12H":    36 * time.Hour,
+		"PT0.5S":     500 * time.Millisecond,
+		"PT1,5S":     1500 * time.Millisecond,
+		"-PT10S":     -10 * time.Second,
+		"3d":         72 * time.Hour,
+		"1w2d12h":    9*24*time.Hour + 12*time.Hour,
+		"90":         90 * time.Second,
+		" 1.5 ":      1500 * time.Millisecond,
+		"0":          0,
+		"2d30m15s":   48*time.Hour + 30*time.Minute + 15*time.Second,
+		"PT36H":      36 * time.Hour,
+		"P0D":        0,
+		"P2DT30M":    48*time.Hour + 30*time.Minute,
+		"PT1H0M0.1S": time.Hour + 100*time.Millisecond,
+	} {
+		var inputs withDuration
+		e := withDurationDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Val, expected, input)
+		assertEqual(t, inputs.A.Present, true, input)
+	}
+}
+
+func TestDurationJSONNumbers(t *testing.T) {
+	var inputs withDuration
+
+	e := withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":3600}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, time.Hour)
+
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":0.25}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, 250*time.Millisecond)
+
+	e = withDurationDecoder.DecodeMap(&inputs, map[string]interface{}{"a": 60})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, time.Minute)
+
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":"PT2M"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, 2*time.Minute)
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestDurationUnit(t *testing.T) {
+	var inputs struct {
+		A Duration `meta_unit:"ms"`
+		B Duration `meta_unit:"d"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"a":1500,"b":"2"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, 1500*time.Millisecond)
+	assertEqual(t, inputs.B.Val, 48*time.Hour)
+
+	// units with a suffix are not affected
+	e = d.DecodeJSON(&inputs, []byte(`{"a":"2s","b":"1h"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, 2*time.Second)
+	assertEqual(t, inputs.B.Val, time.Hour)
+}
+
+func TestDurationFailure(t *testing.T) {
+	for _, input := range []string{"abc", "1x", "P", "PT", "P1Y", "P1M", "P1DT", "1h-3d", "d"} {
+		var inputs withDuration
+		e := withDurationDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash{"a": ErrDuration}, input)
+		assertEqual(t, inputs.A.Present, false, input)
+	}
+
+	var inputs withDuration
+	e := withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":true}`))
+	assertEqual(t, e, ErrorHash{"a": ErrDuration})
+
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":1e300}`))
+	assertEqual(t, e, ErrorHash{"a": ErrDurationRange})
+
+	// 2^63 nanoseconds, which float64(math.MaxInt64) rounds to
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":9223372036.854775808}`))
+	assertEqual(t, e, ErrorHash{"a": ErrDurationRange})
+
+	for _, input := range []string{"PT9223372036.854775808S", "P15250W2D", "106752d", "106751d23h59m59s", "99999999999999999999d"} {
+		e = withDurationDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash{"a": ErrDuration}, input)
+	}
+
+	e = withDurationDecoder.DecodeValues(&inputs, url.Values{"a": {"106751d23h"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withDurationDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestDurationRange(t *testing.T) {
+	var inputs struct {
+		A Duration `meta_min:"PT1M" meta_max:"1d"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"1m"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"24h"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"59"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"P1DT1S"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+}
+
+func TestDurationNull(t *testing.T) {
+	var inputs struct {
+		A Duration `meta_null:"true"`
+	}
+
+	e := NewDecoder(&inputs).DecodeJSON(&inputs, []byte(`{"a":null}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.A.Null, true)
+}
+
+func TestDurationJSON(t *testing.T) {
+	d := NewDuration(90 * time.Minute)
+
+	bs, err := json.Marshal(d)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"1h30m0s"`)
+
+	var decoded Duration
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val, 90*time.Minute)
+	assertEqual(t, decoded.Present, true)
+
+	err = json.Unmarshal([]byte(`"P1D"`), &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val, 24*time.Hour)
+
+	v, err := d.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, int64(90*time.Minute))
+}
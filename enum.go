@@ -0,0 +1,318 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//
+// Core Types and Structures
+//
+
+// Enum is a string restricted to the values of an EnumSet registered for T with RegisterEnum, eg
+//
+//	type Status string
+//
+//	const (
+//		StatusOpen   Status = "open"
+//		StatusClosed Status = "closed"
+//	)
+//
+//	var Statuses = meta.RegisterEnum(StatusOpen, StatusClosed).Alias("done", StatusClosed)
+//
+// and then a `Status meta.Enum[Status]` field.
+type Enum[T ~string] struct {
+	Val T
+	Nullity
+	Presence
+	Path string
+}
+
+type EnumOptions[T ~string] struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// CaseInsensitive matches values and aliases regardless of case. The decoded value is always
+	// the registered constant.
+	// Configured via meta_case_insensitive tag, or EnumSet.CaseInsensitive for every field of T.
+	// Default: false
+	CaseInsensitive bool
+	// In restricts the field to a subset of the registered values, eg for an endpoint that can only
+	// set some statuses. Aliases of these values are accepted too.
+	// Configured via meta_in tag.
+	// Default: nil (every registered value)
+	In  []T
+	Set *EnumSet[T]
+}
+
+// EnumSet holds the values accepted by Enum[T], their aliases and the deprecated values. Its
+// methods are not safe to call while decoding, so the set is meant to be built at init time, right
+// after RegisterEnum.
+type EnumSet[T ~string] struct {
+	values          []T
+	aliases         map[string]T
+	deprecated      map[T]string
+	caseInsensitive bool
+}
+
+// DeprecatedEnumHandler is called when a deprecated enum value, or an alias of one, is decoded,
+// with the path, the value and the deprecation message. It does nothing by default. It is meant to
+// be set at init time, since decoding reads it without a lock.
+var DeprecatedEnumHandler = func(path string, value string, message string) {}
+
+var (
+	enumSetsMu sync.RWMutex
+	enumSets   = map[reflect.Type]interface{}{}
+)
+
+//
+// Registration
+//
+
+// RegisterEnum registers the values accepted by Enum[T] and returns their set to add aliases and
+// deprecations to. Registering T again replaces its set. It is meant to be called at init time,
+// before decoders with Enum[T] fields are created.
+func RegisterEnum[T ~string](values ...T) *EnumSet[T] {
+	set := &EnumSet[T]{
+		aliases:    map[string]T{},
+		deprecated: map[T]string{},
+	}
+	for _, v := range values {
+		set.add(v)
+	}
+
+	enumSetsMu.Lock()
+	enumSets[reflect.TypeOf(T(""))] = set
+	enumSetsMu.Unlock()
+	return set
+}
+
+// LookupEnum returns the set registered for T, if any.
+func LookupEnum[T ~string]() (*EnumSet[T], bool) {
+	enumSetsMu.RLock()
+	defer enumSetsMu.RUnlock()
+	set, ok := enumSets[reflect.TypeOf(T(""))].(*EnumSet[T])
+	return set, ok
+}
+
+// Alias makes alias decode to value, eg Alias("colour", Color). Like the other EnumSet mutators it is
+// meant to be called at init time.
+func (s *EnumSet[T]) Alias(alias string, value T) *EnumSet[T] {
+	s.add(value)
+	s.aliases[alias] = value
+	return s
+}
+
+// Deprecate keeps accepting value, reporting message through DeprecatedEnumHandler when it is decoded.
+func (s *EnumSet[T]) Deprecate(value T, message string) *EnumSet[T] {
+	s.add(value)
+	s.deprecated[value] = message
+	return s
+}
+
+// CaseInsensitive makes every Enum[T] field match regardless of case, unless its
+// meta_case_insensitive tag is "false".
+func (s *EnumSet[T]) CaseInsensitive() *EnumSet[T] {
+	s.caseInsensitive = true
+	return s
+}
+
+// Values returns the registered values in registration order, deprecated ones included.
+func (s *EnumSet[T]) Values() []T {
+	return append([]T(nil), s.values...)
+}
+
+// Contains reports whether value is registered, aliases excluded.
+func (s *EnumSet[T]) Contains(value T) bool {
+	return containsString(enumStrings(s.values), string(value))
+}
+
+// Deprecated returns the deprecation message of value, and whether it is deprecated.
+func (s *EnumSet[T]) Deprecated(value T) (string, bool) {
+	message, ok := s.deprecated[value]
+	return message, ok
+}
+
+func (s *EnumSet[T]) add(value T) {
+	if !s.Contains(value) {
+		s.values = append(s.values, value)
+	}
+}
+
+// match returns the registered value for input, trying values, then aliases, then both
+// regardless of case when caseInsensitive is set.
+func (s *EnumSet[T]) match(input string, caseInsensitive bool) (T, bool) {
+	for _, v := range s.values {
+		if string(v) == input {
+			return v, true
+		}
+	}
+	if v, ok := s.aliases[input]; ok {
+		return v, true
+	}
+	if !caseInsensitive {
+		return "", false
+	}
+
+	for _, v := range s.values {
+		if strings.EqualFold(string(v), input) {
+			return v, true
+		}
+	}
+	for alias, v := range s.aliases {
+		if strings.EqualFold(alias, input) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+//
+// Constructors
+//
+
+func NewEnum[T ~string](value T) Enum[T] {
+	return Enum[T]{value, Nullity{false}, Presence{true}, ""}
+}
+
+//
+// Core Enum Methods
+//
+
+func (e *Enum[T]) ParseOptions(tag reflect.StructTag) interface{} {
+	set, ok := LookupEnum[T]()
+	if !ok {
+		panic(fmt.Sprintf("meta: no enum registered for %T", T("")))
+	}
+
+	opts := &EnumOptions[T]{
+		Required:        tag.Get("meta_required") == "true",
+		DiscardBlank:    tag.Get("meta_discard_blank") != "false",
+		Null:            tag.Get("meta_null") == "true",
+		CaseInsensitive: set.caseInsensitive,
+		Set:             set,
+	}
+
+	switch tag.Get("meta_case_insensitive") {
+	case "true":
+		opts.CaseInsensitive = true
+	case "false":
+		opts.CaseInsensitive = false
+	}
+
+	if in := tag.Get("meta_in"); in != "" {
+		for _, s := range strings.Split(in, ",") {
+			v, ok := set.match(strings.TrimSpace(s), opts.CaseInsensitive)
+			if !ok {
+				panic(fmt.Sprintf("meta: meta_in value %q is not registered for %T", s, T("")))
+			}
+			opts.In = append(opts.In, v)
+		}
+	}
+
+	return opts
+}
+
+func (e *Enum[T]) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	e.Path = path
+	if i == nil {
+		return e.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return e.FormValue(value, options)
+	case T:
+		return e.FormValue(string(value), options)
+	}
+	return ErrString
+}
+
+func (e *Enum[T]) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*EnumOptions[T])
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if opts.Null {
+			e.Present = true
+			e.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			e.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	v, ok := opts.Set.match(value, opts.CaseInsensitive)
+	if !ok {
+		return ErrIn
+	}
+	if len(opts.In) > 0 && !containsString(enumStrings(opts.In), string(v)) {
+		return ErrIn
+	}
+
+	if message, deprecated := opts.Set.deprecated[v]; deprecated {
+		DeprecatedEnumHandler(e.Path, value, message)
+	}
+
+	e.Val = v
+	e.Present = true
+	return nil
+}
+
+func (e Enum[T]) Value() (driver.Value, error) {
+	if e.Present && !e.Null {
+		return string(e.Val), nil
+	}
+	return nil, nil
+}
+
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	if e.Present && !e.Null {
+		return MetaJson.Marshal(string(e.Val))
+	}
+	return nullString, nil
+}
+
+// UnmarshalJSON accepts the registered values and aliases of T, or any string when T isn't registered.
+func (e *Enum[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		e.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	v := T(s)
+	if set, ok := LookupEnum[T](); ok {
+		if v, ok = set.match(s, set.caseInsensitive); !ok {
+			return fmt.Errorf("meta: %q is not a registered %T", s, T(""))
+		}
+	}
+
+	e.Val = v
+	e.Presence = Presence{true}
+	e.Nullity = Nullity{false}
+	return nil
+}
+
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
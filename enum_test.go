@@ -0,0 +1,155 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	testStatusOpen    testStatus = "open"
+	testStatusPlanned testStatus = "planned"
+	testStatusClosed  testStatus = "closed"
+	testStatusLegacy  testStatus = "under_review"
+)
+
+var testStatuses = RegisterEnum(testStatusOpen, testStatusPlanned, testStatusClosed).
+	Alias("done", testStatusClosed).
+	Deprecate(testStatusLegacy, "use planned")
+
+type testColor string
+
+var testColors = RegisterEnum[testColor]("red", "color").Alias("colour", "color").CaseInsensitive()
+
+type withEnum struct {
+	A Enum[testStatus] `meta_required:"true"`
+}
+
+var withEnumDecoder = NewDecoder(&withEnum{})
+
+func TestEnumSuccess(t *testing.T) {
+	for input, expected := range map[string]testStatus{
+		"open":         testStatusOpen,
+		" planned ":    testStatusPlanned,
+		"closed":       testStatusClosed,
+		"done":         testStatusClosed,
+		"under_review": testStatusLegacy,
+	} {
+		var inputs withEnum
+		e := withEnumDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Val, expected, input)
+		assertEqual(t, inputs.A.Present, true, input)
+	}
+
+	var inputs withEnum
+	e := withEnumDecoder.DecodeJSON(&inputs, []byte(`{"a":"open"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, testStatusOpen)
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestEnumInvalid(t *testing.T) {
+	var inputs withEnum
+
+	for _, bad := range []string{"Open", "DONE", "reopened"} {
+		e := withEnumDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrIn}, bad)
+	}
+
+	e := withEnumDecoder.DecodeJSON(&inputs, []byte(`{"a":1}`))
+	assertEqual(t, e, ErrorHash{"a": ErrString})
+
+	e = withEnumDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withEnumDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestEnumCaseInsensitive(t *testing.T) {
+	var inputs struct {
+		Status Enum[testStatus] `meta_case_insensitive:"true"`
+		Color  Enum[testColor]
+		Exact  Enum[testColor] `meta_case_insensitive:"false"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"status": {"DONE"}, "color": {"Colour"}, "exact": {"colour"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Status.Val, testStatusClosed)
+	assertEqual(t, inputs.Color.Val, testColor("color"))
+	assertEqual(t, inputs.Exact.Val, testColor("color"))
+
+	e = d.DecodeValues(&inputs, url.Values{"exact": {"RED"}})
+	assertEqual(t, e, ErrorHash{"exact": ErrIn})
+}
+
+func TestEnumIn(t *testing.T) {
+	var inputs struct {
+		A Enum[testStatus] `meta_in:"open,done"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"closed"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, testStatusClosed)
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"planned"}})
+	assertEqual(t, e, ErrorHash{"a": ErrIn})
+}
+
+func TestEnumDeprecated(t *testing.T) {
+	var warnings []string
+	defer func(handler func(string, string, string)) { DeprecatedEnumHandler = handler }(DeprecatedEnumHandler)
+	DeprecatedEnumHandler = func(path, value, message string) {
+		warnings = append(warnings, path+" "+value+": "+message)
+	}
+
+	var inputs struct {
+		A []Enum[testStatus]
+	}
+	e := NewDecoder(&inputs).DecodeJSON(&inputs, []byte(`{"a":["open","under_review"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, len(inputs.A), 2)
+	assertEqual(t, warnings, []string{"a.1 under_review: use planned"})
+
+	message, ok := testStatuses.Deprecated(testStatusLegacy)
+	assertEqual(t, ok, true)
+	assertEqual(t, message, "use planned")
+	assertEqual(t, testStatuses.Values(), []testStatus{testStatusOpen, testStatusPlanned, testStatusClosed, testStatusLegacy})
+	assert(t, testColors.Contains("red"))
+}
+
+func TestEnumUnregistered(t *testing.T) {
+	type unregistered string
+	defer func() {
+		assert(t, recover() != nil)
+	}()
+	var inputs struct {
+		A Enum[unregistered]
+	}
+	NewDecoder(&inputs)
+}
+
+func TestEnumJSON(t *testing.T) {
+	v := NewEnum(testStatusPlanned)
+
+	bs, err := json.Marshal(v)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"planned"`)
+
+	var decoded Enum[testStatus]
+	err = json.Unmarshal([]byte(`"done"`), &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val, testStatusClosed)
+
+	err = json.Unmarshal([]byte(`"nope"`), &decoded)
+	assert(t, err != nil)
+
+	dv, err := v.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, dv, "planned")
+}
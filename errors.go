@@ -78,4 +78,42 @@ var (
 	ErrIn         = ErrorAtom("in")
 	ErrMinLength  = ErrorAtom("min_length")
 	ErrMaxLength  = ErrorAtom("max_length")
+	ErrQuote      = ErrorAtom("quote")
+	ErrUnique     = ErrorAtom("unique")
+	ErrSorted     = ErrorAtom("sorted")
+	ErrPattern    = ErrorAtom("pattern")
+	ErrMarkup     = ErrorAtom("markup")
+
+	ErrURL            = ErrorAtom("url")
+	ErrURLScheme      = ErrorAtom("url_scheme")
+	ErrURLHost        = ErrorAtom("url_host")
+	ErrPrivateAddress = ErrorAtom("private_address")
+
+	ErrIP               = ErrorAtom("ip")
+	ErrIPFamily         = ErrorAtom("ip_family")
+	ErrPrefix           = ErrorAtom("prefix")
+	ErrMulticastAddress = ErrorAtom("multicast_address")
+
+	ErrDuration      = ErrorAtom("duration")
+	ErrDurationRange = ErrorAtom("duration_range")
+
+	ErrDate      = ErrorAtom("date")
+	ErrTimeOfDay = ErrorAtom("time_of_day")
+
+	ErrLocation       = ErrorAtom("location")
+	ErrLocationOffset = ErrorAtom("location_offset")
+
+	ErrTimeRange     = ErrorAtom("time_range")
+	ErrTimeRangeSpan = ErrorAtom("time_range_span")
+
+	ErrRecurrence          = ErrorAtom("recurrence")
+	ErrRecurrenceFrequency = ErrorAtom("recurrence_frequency")
+
+	ErrCron         = ErrorAtom("cron")
+	ErrCronInterval = ErrorAtom("cron_interval")
+
+	ErrInvalid = ErrorAtom("invalid")
+
+	ErrSecretClass  = ErrorAtom("secret_class")
+	ErrCommonSecret = ErrorAtom("common_secret")
 )
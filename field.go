@@ -0,0 +1,333 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//
+// Core Types and Structures
+//
+
+// Field is a value of any type T with a FieldType registered by RegisterField, eg
+//
+//	var _ = meta.RegisterField(meta.FieldType[netip.Addr]{
+//		Parse:  netip.ParseAddr,
+//		Format: netip.Addr.String,
+//	})
+//
+// and then an `Addr meta.Field[netip.Addr]` field. The presence, nullity, blank and JSON handling
+// are the same as for String, Int64 and the other built-in types.
+type Field[T any] struct {
+	Val T
+	Nullity
+	Presence
+	Path string
+}
+
+// FieldType describes how Field[T] parses, validates and renders T.
+type FieldType[T any] struct {
+	// Parse converts form input, JSON strings and SQL text to T. Required.
+	// JSON numbers and booleans are passed in their literal form, eg "12.5" or "true".
+	Parse func(value string) (T, error)
+	// Format renders T for Value and MarshalJSON. When nil, Value uses
+	// driver.DefaultParameterConverter and MarshalJSON marshals T itself.
+	Format func(value T) string
+	// Options parses the field's struct tag once, when the decoder is created. Its result is
+	// passed to Validate.
+	Options func(tag reflect.StructTag) interface{}
+	// Validate checks a parsed value, eg against limits read by Options.
+	Validate func(value T, options interface{}) Errorable
+	// Error is returned when Parse fails or the JSON value is neither a string, a number nor a bool.
+	// Default: ErrInvalid
+	Error ErrorAtom
+}
+
+type FieldOptions[T any] struct {
+	Required     bool
+	DiscardBlank bool
+	Strip        bool
+	Null         bool
+	// Options is the result of FieldType.Options, if any.
+	Options interface{}
+	Type    *FieldType[T]
+}
+
+var (
+	fieldTypesMu sync.RWMutex
+	fieldTypes   = map[reflect.Type]interface{}{
+		fieldTypeOf[string](): &FieldType[string]{
+			Parse: func(s string) (string, error) { return s, nil },
+			Error: ErrString,
+		},
+		fieldTypeOf[bool](): &FieldType[bool]{
+			Parse: strconv.ParseBool,
+			Error: ErrBool,
+		},
+		fieldTypeOf[int](): &FieldType[int]{
+			Parse: strconv.Atoi,
+			Error: ErrInt,
+		},
+		fieldTypeOf[int64](): &FieldType[int64]{
+			Parse: func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) },
+			Error: ErrInt,
+		},
+		fieldTypeOf[uint64](): &FieldType[uint64]{
+			Parse: func(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) },
+			Error: ErrInt,
+		},
+		fieldTypeOf[float64](): &FieldType[float64]{
+			Parse: func(s string) (float64, error) { return strconv.ParseFloat(s, 64) },
+			Error: ErrFloat,
+		},
+	}
+)
+
+//
+// Registration
+//
+
+// RegisterField registers how Field[T] handles T. Registering T again replaces its type. It is
+// meant to be called at init time, before decoders with Field[T] fields are created.
+// string, bool, int, int64, uint64 and float64 are registered by default.
+func RegisterField[T any](ft FieldType[T]) *FieldType[T] {
+	if ft.Parse == nil {
+		panic(fmt.Sprintf("meta: FieldType[%s] needs a Parse function", fieldTypeOf[T]()))
+	}
+	if ft.Error == "" {
+		ft.Error = ErrInvalid
+	}
+
+	fieldTypesMu.Lock()
+	fieldTypes[fieldTypeOf[T]()] = &ft
+	fieldTypesMu.Unlock()
+	return &ft
+}
+
+// LookupField returns the FieldType registered for T, if any.
+func LookupField[T any]() (*FieldType[T], bool) {
+	fieldTypesMu.RLock()
+	defer fieldTypesMu.RUnlock()
+	ft, ok := fieldTypes[fieldTypeOf[T]()].(*FieldType[T])
+	return ft, ok
+}
+
+func fieldTypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+//
+// Constructors
+//
+
+func NewField[T any](value T) Field[T] {
+	return Field[T]{value, Nullity{false}, Presence{true}, ""}
+}
+
+//
+// Core Field Methods
+//
+
+func (f *Field[T]) ParseOptions(tag reflect.StructTag) interface{} {
+	ft, ok := LookupField[T]()
+	if !ok {
+		panic(fmt.Sprintf("meta: no FieldType registered for %s", fieldTypeOf[T]()))
+	}
+
+	opts := &FieldOptions[T]{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Strip:        tag.Get("meta_strip") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		Type:         ft,
+	}
+
+	if ft.Options != nil {
+		opts.Options = ft.Options(tag)
+	}
+
+	return opts
+}
+
+func (f *Field[T]) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	f.Path = path
+	if i == nil {
+		opts := options.(*FieldOptions[T])
+		if opts.Null {
+			f.Present = true
+			f.Null = true
+			return nil
+		}
+		return f.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return f.FormValue(value, options)
+	case json.Number:
+		return f.FormValue(value.String(), options)
+	case bool:
+		return f.FormValue(strconv.FormatBool(value), options)
+	case T:
+		return f.validate(value, options.(*FieldOptions[T]))
+	}
+	return options.(*FieldOptions[T]).Type.Error
+}
+
+func (f *Field[T]) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*FieldOptions[T])
+
+	if opts.Strip {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		if opts.Null {
+			f.Present = true
+			f.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			f.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	v, err := opts.Type.Parse(value)
+	if err != nil {
+		return opts.Type.Error
+	}
+	return f.validate(v, opts)
+}
+
+func (f *Field[T]) validate(value T, opts *FieldOptions[T]) Errorable {
+	if opts.Type.Validate != nil {
+		if err := opts.Type.Validate(value, opts.Options); err != nil {
+			return err
+		}
+	}
+
+	f.Val = value
+	f.Present = true
+	return nil
+}
+
+// Value uses FieldType.Format when set, or converts T to a driver value like database/sql does.
+func (f Field[T]) Value() (driver.Value, error) {
+	if !f.Present || f.Null {
+		return nil, nil
+	}
+	if ft, ok := LookupField[T](); ok && ft.Format != nil {
+		return ft.Format(f.Val), nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(f.Val)
+}
+
+// Scan reads a SQL value into the field: values of T, or of a type convertible to T without loss,
+// are taken as-is and anything else is parsed from its text. Conversions that would truncate or
+// overflow, eg 2.9 or 300 into an int8, are errors.
+func (f *Field[T]) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*f = Field[T]{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case T:
+		*f = NewField(value)
+		return nil
+	case []byte:
+		return f.scanString(string(value))
+	case string:
+		return f.scanString(value)
+	}
+
+	// numbers convert to string kinds as runes, so those are parsed from text instead
+	t := fieldTypeOf[T]()
+	if v := reflect.ValueOf(src); v.Type().ConvertibleTo(t) && t.Kind() != reflect.String {
+		converted := v.Convert(t)
+		if !losslessConversion(v, converted) {
+			return fmt.Errorf("meta: cannot scan %v into Field[%s] without loss", src, t)
+		}
+		*f = NewField(converted.Interface().(T))
+		return nil
+	}
+	return f.scanString(fmt.Sprint(src))
+}
+
+// losslessConversion reports whether converted holds the value of v: converting it back gives v, and
+// a conversion between signed and unsigned integers kept the sign.
+func losslessConversion(v, converted reflect.Value) bool {
+	if !v.Type().Comparable() {
+		return true
+	}
+	if converted.Convert(v.Type()).Interface() != v.Interface() {
+		return false
+	}
+	switch {
+	case v.CanInt() && converted.CanUint():
+		return v.Int() >= 0
+	case v.CanUint() && converted.CanInt():
+		return converted.Int() >= 0
+	}
+	return true
+}
+
+func (f *Field[T]) scanString(value string) error {
+	ft, ok := LookupField[T]()
+	if !ok {
+		return fmt.Errorf("meta: cannot scan %q into Field[%s]", value, fieldTypeOf[T]())
+	}
+	v, err := ft.Parse(value)
+	if err != nil {
+		return err
+	}
+	*f = NewField(v)
+	return nil
+}
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.Present || f.Null {
+		return nullString, nil
+	}
+	if ft, ok := LookupField[T](); ok && ft.Format != nil {
+		return MetaJson.Marshal(ft.Format(f.Val))
+	}
+	return MetaJson.Marshal(f.Val)
+}
+
+// UnmarshalJSON reads what MarshalJSON writes: a string parsed by FieldType.Parse when T has a
+// Format, or T's own JSON otherwise.
+func (f *Field[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		f.Nullity = Nullity{true}
+		return nil
+	}
+
+	var v T
+	if ft, ok := LookupField[T](); ok && ft.Format != nil {
+		var s string
+		if err := MetaJson.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		parsed, err := ft.Parse(s)
+		if err != nil {
+			return err
+		}
+		v = parsed
+	} else if err := MetaJson.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	f.Val = v
+	f.Presence = Presence{true}
+	f.Nullity = Nullity{false}
+	return nil
+}
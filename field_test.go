@@ -0,0 +1,180 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var _ = RegisterField(FieldType[netip.Addr]{
+	Parse:  netip.ParseAddr,
+	Format: netip.Addr.String,
+	Options: func(tag reflect.StructTag) interface{} {
+		return tag.Get("meta_family")
+	},
+	Validate: func(addr netip.Addr, options interface{}) Errorable {
+		if options == "ipv4" && !addr.Is4() {
+			return ErrIPFamily
+		}
+		return nil
+	},
+	Error: ErrIP,
+})
+
+type withField struct {
+	A Field[netip.Addr] `meta_required:"true" meta_family:"ipv4"`
+	B Field[int]        `meta_null:"true"`
+	C Field[float64]
+	D Field[bool] `meta_discard_blank:"false"`
+}
+
+var withFieldDecoder = NewDecoder(&withField{})
+
+func TestFieldSuccess(t *testing.T) {
+	var inputs withField
+	e := withFieldDecoder.DecodeValues(&inputs, url.Values{"a": {" 10.0.0.1 "}, "b": {"42"}, "c": {"1.5"}, "d": {"true"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, netip.MustParseAddr("10.0.0.1"))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.B.Val, 42)
+	assertEqual(t, inputs.C.Val, 1.5)
+	assertEqual(t, inputs.D.Val, true)
+
+	inputs = withField{}
+	e = withFieldDecoder.DecodeJSON(&inputs, []byte(`{"a":"10.0.0.2","b":null,"c":2,"d":false}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Path, "a")
+	assertEqual(t, inputs.B.Present, true)
+	assertEqual(t, inputs.B.Null, true)
+	assertEqual(t, inputs.C.Val, 2.0)
+	assertEqual(t, inputs.D.Present, true)
+	assertEqual(t, inputs.D.Val, false)
+}
+
+func TestFieldInvalid(t *testing.T) {
+	var inputs withField
+
+	e := withFieldDecoder.DecodeValues(&inputs, url.Values{"a": {"::1"}, "b": {"4.2"}, "c": {"x"}, "d": {""}})
+	assertEqual(t, e, ErrorHash{"a": ErrIPFamily, "b": ErrInt, "c": ErrFloat, "d": ErrBlank})
+
+	e = withFieldDecoder.DecodeJSON(&inputs, []byte(`{"a":"nope","c":{}}`))
+	assertEqual(t, e, ErrorHash{"a": ErrIP, "c": ErrFloat})
+
+	e = withFieldDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withFieldDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestFieldUnregistered(t *testing.T) {
+	type unregistered struct{ X int }
+	defer func() {
+		assert(t, recover() != nil)
+	}()
+	var inputs struct {
+		A Field[unregistered]
+	}
+	NewDecoder(&inputs)
+}
+
+func TestFieldJSON(t *testing.T) {
+	inputs := withField{
+		A: NewField(netip.MustParseAddr("10.0.0.1")),
+		B: NewField(7),
+		D: Field[bool]{Nullity: Nullity{true}, Presence: Presence{true}},
+	}
+
+	bs, err := json.Marshal(inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"A":"10.0.0.1","B":7,"C":null,"D":null}`)
+
+	var decoded withField
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.A.Val, netip.MustParseAddr("10.0.0.1"))
+	assertEqual(t, decoded.B.Val, 7)
+	assertEqual(t, decoded.B.Present, true)
+	assertEqual(t, decoded.D.Null, true)
+
+	err = json.Unmarshal([]byte(`{"A":"nope"}`), &decoded)
+	assert(t, err != nil)
+}
+
+func TestFieldSQL(t *testing.T) {
+	v, err := NewField(netip.MustParseAddr("10.0.0.1")).Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "10.0.0.1")
+
+	v, err = NewField(7).Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, int64(7))
+
+	v, err = Field[int]{}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, nil)
+
+	var addr Field[netip.Addr]
+	err = addr.Scan([]byte("192.168.1.1"))
+	assertEqual(t, err, nil)
+	assertEqual(t, addr.Val, netip.MustParseAddr("192.168.1.1"))
+	assertEqual(t, addr.Present, true)
+
+	var n Field[int]
+	err = n.Scan(int64(12))
+	assertEqual(t, err, nil)
+	assertEqual(t, n.Val, 12)
+
+	err = n.Scan("13")
+	assertEqual(t, err, nil)
+	assertEqual(t, n.Val, 13)
+
+	err = n.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, n.Present, true)
+	assertEqual(t, n.Null, true)
+
+	var s Field[string]
+	err = s.Scan(int64(65))
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, "65")
+
+	err = n.Scan("x")
+	assert(t, err != nil)
+
+	// conversions must not lose anything
+	err = n.Scan(float64(3))
+	assertEqual(t, err, nil)
+	assertEqual(t, n.Val, 3)
+
+	err = n.Scan(2.9)
+	assert(t, err != nil)
+
+	var small Field[int8]
+	err = small.Scan(int64(-128))
+	assertEqual(t, err, nil)
+	assertEqual(t, small.Val, int8(-128))
+
+	for _, src := range []interface{}{int64(300), int64(-129), 1.5} {
+		err = small.Scan(src)
+		assert(t, err != nil, src)
+	}
+
+	var u Field[uint]
+	err = u.Scan(int64(-1))
+	assert(t, err != nil)
+
+	var i64 Field[int64]
+	err = i64.Scan(uint64(1 << 63))
+	assert(t, err != nil)
+
+	var f Field[float32]
+	err = f.Scan(0.5)
+	assertEqual(t, err, nil)
+	assertEqual(t, f.Val, float32(0.5))
+
+	err = f.Scan(0.1)
+	assert(t, err != nil)
+}
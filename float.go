@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -168,6 +169,35 @@ func (i Float64) Value() (driver.Value, error) {
 	return nil, nil
 }
 
+// Scan reads SQL floating point and integer columns, and NUMERIC columns returned as text.
+func (i *Float64) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*i = Float64{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case float64:
+		*i = NewFloat64(value)
+		return nil
+	case int64:
+		*i = NewFloat64(float64(value))
+		return nil
+	case string:
+		return i.scanString(value)
+	case []byte:
+		return i.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Float64", src)
+}
+
+func (i *Float64) scanString(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*i = NewFloat64(f)
+	return nil
+}
+
 func (i Float64) MarshalJSON() ([]byte, error) {
 	if i.Present && !i.Null {
 		return MetaJson.Marshal(i.Val)
@@ -0,0 +1,269 @@
+package meta
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//
+// Float64Slice
+//
+
+type Float64Slice struct {
+	Presence
+	Nullity
+	Val  []float64
+	Path string
+	// sqlFormat is how Value stores the slice, "" for SQLFormatArray
+	sqlFormat string
+}
+
+type FloatSliceOptions struct {
+	*FloatOptions
+	*SliceOptions
+}
+
+func (i *Float64Slice) ParseOptions(tag reflect.StructTag) interface{} {
+	var tempF Float64
+	opts := tempF.ParseOptions(tag)
+
+	return &FloatSliceOptions{
+		FloatOptions: opts.(*FloatOptions),
+		SliceOptions: ParseSliceOptions(tag),
+	}
+}
+
+func (n *Float64Slice) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	opts := options.(*FloatSliceOptions)
+	floatOpts := opts.FloatOptions
+	sliceOpts := opts.SliceOptions
+
+	n.Path = path
+	n.Val = nil
+	n.sqlFormat = sliceOpts.SQLFormat
+	n.Present = true
+	n.Null = false
+
+	if i == nil {
+		n.Null = true
+		if sliceOpts.DiscardBlank {
+			n.Present = false
+			return nil
+		} else if sliceOpts.Null {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	var errorsInSlice ErrorSlice
+	switch value := i.(type) {
+	case string:
+		return n.FormValue(value, options)
+	case []string:
+		return n.formValues(value, options)
+	case []interface{}:
+		n.Val = []float64{}
+
+		if sliceOpts.MinLengthPresent && len(value) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(value) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(value) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+
+		for _, v := range value {
+			var num Float64
+			if err := num.JSONValue("", v, floatOpts); err != nil {
+				errorsInSlice = append(errorsInSlice, err)
+			} else {
+				errorsInSlice = append(errorsInSlice, nil)
+				n.Val = append(n.Val, num.Val)
+			}
+		}
+		if errorsInSlice.Len() > 0 {
+			return errorsInSlice
+		}
+
+		var err Errorable
+		if n.Val, err = constrainValues(n.Val, sliceOpts); err != nil {
+			return err
+		}
+
+		if sliceOpts.MinLengthPresent && len(n.Val) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(n.Val) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(n.Val) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+	}
+	return nil
+}
+
+func (i *Float64Slice) FormValue(value string, options interface{}) Errorable {
+	return i.formValues([]string{value}, options)
+}
+
+// formValues decodes the values of a form key, repeated keys giving several values.
+func (i *Float64Slice) formValues(values []string, options interface{}) Errorable {
+	var tempF Float64
+
+	opts := options.(*FloatSliceOptions)
+	floatOpts := opts.FloatOptions
+	sliceOpts := opts.SliceOptions
+
+	i.Val = []float64{}
+	i.sqlFormat = sliceOpts.SQLFormat
+	i.Present = true
+	i.Null = false
+
+	values = append([]string(nil), values...)
+	blank := true
+	for j, value := range values {
+		values[j] = strings.TrimSpace(value)
+		blank = blank && values[j] == ""
+	}
+
+	if blank {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	strs, err := sliceOpts.split(values)
+	if err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(strs) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(strs) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	var errorsInSlice ErrorSlice
+	for _, s := range strs {
+		tempF.Val = 0
+		if err := tempF.FormValue(strings.TrimSpace(s), floatOpts); err != nil {
+			errorsInSlice = append(errorsInSlice, err)
+		} else {
+			errorsInSlice = append(errorsInSlice, nil)
+			i.Val = append(i.Val, tempF.Val)
+		}
+	}
+
+	if errorsInSlice.Len() > 0 {
+		return errorsInSlice
+	}
+
+	if i.Val, err = constrainValues(i.Val, sliceOpts); err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(i.Val) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(i.Val) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	if len(i.Val) == 0 {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	return nil
+}
+
+// Value stores the slice as a Postgres array literal, eg "{1.5,2}", or as a JSON array when the
+// field's meta_sql_format is "json".
+func (s Float64Slice) Value() (driver.Value, error) {
+	if s.Null || (!s.Present && s.Val == nil) {
+		return nil, nil
+	}
+	values := make([]driver.Value, len(s.Val))
+	for i, v := range s.Val {
+		values[i] = v
+	}
+	return encodeSQLSlice(values, s.sqlFormat)
+}
+
+// WithSQLFormat returns a copy of s that Value stores in format, SQLFormatArray or SQLFormatJSON.
+func (s Float64Slice) WithSQLFormat(format string) Float64Slice {
+	s.sqlFormat = parseSQLFormat(format)
+	return s
+}
+
+// Scan reads Postgres float and numeric arrays, eg "{1.5,2}", and JSON arrays of numbers. The SQL format of s
+// is kept.
+func (s *Float64Slice) Scan(src interface{}) error {
+	if src == nil {
+		*s = Float64Slice{Nullity: Nullity{true}, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+		return nil
+	}
+
+	elems, err := scanSliceStrings(src)
+	if err != nil {
+		return err
+	}
+	val := make([]float64, len(elems))
+	for i, elem := range elems {
+		if val[i], err = strconv.ParseFloat(elem, 64); err != nil {
+			return err
+		}
+	}
+	*s = Float64Slice{Val: val, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+	return nil
+}
+
+func (s Float64Slice) MarshalJSON() ([]byte, error) {
+	if len(s.Val) > 0 {
+		return MetaJson.Marshal(s.Val)
+	}
+	return nullString, nil
+}
+
+func (s *Float64Slice) UnmarshalJSON(data []byte) error {
+	var value []float64
+	err := MetaJson.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	*s = Float64Slice{Val: value}
+	return nil
+}
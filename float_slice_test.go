@@ -0,0 +1,78 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type withFloatSlice struct {
+	A Float64Slice `meta_min:"0"`
+}
+
+var withFloatSliceDecoder = NewDecoder(&withFloatSlice{})
+
+func TestFloatSliceSuccess(t *testing.T) {
+	var inputs withFloatSlice
+
+	e := withFloatSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"1.5, 2.0,3"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []float64{1.5, 2, 3})
+	assertEqual(t, inputs.A.Present, true)
+
+	e = withFloatSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[1.5,2,"3.25"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []float64{1.5, 2, 3.25})
+
+	e = withFloatSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":"0.5"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []float64{0.5})
+
+	e = withFloatSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, false)
+}
+
+func TestFloatSliceInvalid(t *testing.T) {
+	var inputs withFloatSlice
+
+	e := withFloatSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"1.5,x,-1"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{nil, ErrFloat, ErrMin}})
+
+	e = withFloatSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[true,1]}`))
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{ErrFloat, nil}})
+
+	var lengths struct {
+		A Float64Slice `meta_required:"true" meta_max_length:"2"`
+	}
+	d := NewDecoder(&lengths)
+	e = d.DecodeValues(&lengths, url.Values{"a": {"1,2,3"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMaxLength})
+
+	e = d.DecodeValues(&lengths, url.Values{})
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestFloatSliceSQL(t *testing.T) {
+	s := Float64Slice{Val: []float64{1.5, 2}}
+	v, err := s.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{1.5,2}")
+
+	v, err = s.WithSQLFormat("json").Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "[1.5,2]")
+
+	var scanned Float64Slice
+	err = scanned.Scan([]byte("{1.5,2}"))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []float64{1.5, 2})
+
+	err = scanned.Scan("[3.5]")
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []float64{3.5})
+
+	bs, err := json.Marshal(scanned)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "[3.5]")
+}
@@ -363,3 +363,26 @@ func TestOptionalNullFloatOmitted(t *testing.T) {
 	assertEqual(t, inputs.A.Null, false)
 	assertEqual(t, inputs.A.Val, float64(0))
 }
+
+func TestFloat64Scan(t *testing.T) {
+	var f Float64
+
+	err := f.Scan(1.5)
+	assertEqual(t, err, nil)
+	assertEqual(t, f, NewFloat64(1.5))
+
+	err = f.Scan(int64(3))
+	assertEqual(t, err, nil)
+	assertEqual(t, f.Val, 3.0)
+
+	err = f.Scan([]byte("12.25"))
+	assertEqual(t, err, nil)
+	assertEqual(t, f.Val, 12.25)
+
+	err = f.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, f, Float64{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = f.Scan("x")
+	assert(t, err != nil)
+}
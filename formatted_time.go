@@ -0,0 +1,65 @@
+package meta
+
+import "bytes"
+
+//
+// FormattedTime
+//
+
+// FormattedTime is a Time that MarshalJSON and MarshalText render in Format rather than RFC 3339,
+// so clients get back the layout they sent. Decoding sets Format from the field's meta_output_format.
+type FormattedTime struct {
+	Time
+	// Format is a predefined name like "DateOnly", a layout or an epoch format like "unix_ms",
+	// see TimeOptions.OutputFormat. "" renders RFC 3339 like Time.
+	Format string
+}
+
+func NewFormattedTime(t Time, format string) FormattedTime {
+	return FormattedTime{t, lookupTimeFormat(format)}
+}
+
+func (t *FormattedTime) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	t.Format = options.(*TimeOptions).OutputFormat
+	return t.Time.JSONValue(path, i, options)
+}
+
+func (t *FormattedTime) FormValue(value string, options interface{}) Errorable {
+	t.Format = options.(*TimeOptions).OutputFormat
+	return t.Time.FormValue(value, options)
+}
+
+// MarshalJSON renders the value in Format: a string for layouts, a whole number of units for
+// epoch formats, and an RFC 3339 string by default.
+func (t FormattedTime) MarshalJSON() ([]byte, error) {
+	if t.Present && !t.Null {
+		return marshalTimeJSON(t.Val, t.Format)
+	}
+	return nullString, nil
+}
+
+// MarshalText renders the value in Format for form and query string encoders. Absent and null
+// values render as "".
+func (t FormattedTime) MarshalText() ([]byte, error) {
+	if !t.Present || t.Null {
+		return []byte{}, nil
+	}
+	return marshalTimeText(t.Val, t.Format)
+}
+
+// UnmarshalJSON reads RFC 3339 strings, strings in Format, and numbers when Format is an epoch format.
+func (t *FormattedTime) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		t.Nullity = Nullity{true}
+		return nil
+	}
+
+	v, err := unmarshalTimeJSON(b, t.Format)
+	if err != nil {
+		return err
+	}
+	t.Val = v
+	t.Presence = Presence{true}
+	t.Nullity = Nullity{false}
+	return nil
+}
@@ -0,0 +1,76 @@
+package meta
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFormattedTime(t *testing.T) {
+	var inputs struct {
+		Day     FormattedTime   `meta_format:"DateOnly" meta_output_format:"DateOnly"`
+		Stamp   FormattedTime   `meta_output_format:"2006-01-02 15:04"`
+		Epoch   FormattedTime   `meta_format:"RFC3339" meta_output_format:"unix_ms"`
+		Default FormattedTime   `meta_format:"DateOnly"`
+		Plain   Time            `meta_format:"DateOnly" meta_output_format:"DateOnly"`
+		Days    []FormattedTime `meta_element_format:"DateOnly" meta_element_output_format:"DateOnly"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"day":"2024-01-01","stamp":"2024-01-01T09:30:15Z","epoch":"2024-01-01T00:00:00.5Z","default":"2024-01-01","plain":"2024-01-01","days":["2024-02-01"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Day.Val, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	bs, err := json.Marshal(inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"Day":"2024-01-01","Stamp":"2024-01-01 09:30","Epoch":1704067200500,"Default":"2024-01-01T00:00:00Z","Plain":"2024-01-01T00:00:00Z","Days":["2024-02-01"]}`)
+
+	text, err := inputs.Day.MarshalText()
+	assertEqual(t, err, nil)
+	assertEqual(t, string(text), "2024-01-01")
+
+	text, err = inputs.Epoch.MarshalText()
+	assertEqual(t, err, nil)
+	assertEqual(t, string(text), "1704067200500")
+
+	text, err = inputs.Default.MarshalText()
+	assertEqual(t, err, nil)
+	assertEqual(t, string(text), "2024-01-01T00:00:00Z")
+
+	err = json.Unmarshal([]byte(`{"Day":"2025-06-30","Stamp":"2025-06-30T10:00:00Z","Epoch":-1}`), &inputs)
+	assertEqual(t, err, nil)
+	assert(t, inputs.Day.Val.Equal(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)))
+	assert(t, inputs.Stamp.Val.Equal(time.Date(2025, 6, 30, 10, 0, 0, 0, time.UTC)))
+	assert(t, inputs.Epoch.Val.Equal(time.Unix(-1, 999000000)))
+
+	err = json.Unmarshal([]byte(`{"Default":1700000000}`), &inputs)
+	assert(t, err != nil)
+}
+
+func TestNewFormattedTime(t *testing.T) {
+	v := NewTime(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))
+
+	bs, err := json.Marshal(NewFormattedTime(v, "DateTime"))
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"2024-05-06 07:08:09"`)
+
+	bs, err = json.Marshal(NewFormattedTime(v, "unix"))
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `1714979289`)
+
+	bs, err = json.Marshal(NewFormattedTime(v, ""))
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"2024-05-06T07:08:09Z"`)
+
+	// scanning keeps the format
+	f := NewFormattedTime(Time{}, "DateOnly")
+	err = f.Scan("2024-03-05 14:30:00+01")
+	assertEqual(t, err, nil)
+	bs, err = json.Marshal(f)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"2024-03-05"`)
+
+	text, err := FormattedTime{}.MarshalText()
+	assertEqual(t, err, nil)
+	assertEqual(t, string(text), "")
+}
@@ -1,3 +1,5 @@
 module github.com/uservoice/meta
 
 go 1.20
+
+require golang.org/x/text v0.22.0
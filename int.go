@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -333,6 +334,61 @@ func (i Uint64) Value() (driver.Value, error) {
 	return nil, nil
 }
 
+func (i *Int64) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*i = Int64{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case int64:
+		*i = NewInt64(value)
+		return nil
+	case string:
+		return i.scanString(value)
+	case []byte:
+		return i.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Int64", src)
+}
+
+func (i *Int64) scanString(value string) error {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = NewInt64(n)
+	return nil
+}
+
+// Scan reads integers, and unsigned values from drivers that return them. An int64 is read back
+// as the uint64 Value wrote, so values above math.MaxInt64, stored as negative int64s, round-trip.
+func (i *Uint64) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*i = Uint64{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case int64:
+		*i = NewUint64(uint64(value))
+		return nil
+	case uint64:
+		*i = NewUint64(value)
+		return nil
+	case string:
+		return i.scanString(value)
+	case []byte:
+		return i.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Uint64", src)
+}
+
+func (i *Uint64) scanString(value string) error {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = NewUint64(n)
+	return nil
+}
+
 func (i Int64) MarshalJSON() ([]byte, error) {
 	if i.Present && !i.Null {
 		return MetaJson.Marshal(i.Val)
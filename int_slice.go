@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"database/sql/driver"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +16,8 @@ type Int64Slice struct {
 	Nullity
 	Val  []int64
 	Path string
+	// sqlFormat is how Value stores the slice, "" for SQLFormatArray
+	sqlFormat string
 }
 
 type IntSliceOptions struct {
@@ -38,6 +42,7 @@ func (n *Int64Slice) JSONValue(path string, i interface{}, options interface{})
 
 	n.Path = path
 	n.Val = nil
+	n.sqlFormat = sliceOpts.SQLFormat
 	n.Present = true
 	n.Null = false
 
@@ -56,6 +61,8 @@ func (n *Int64Slice) JSONValue(path string, i interface{}, options interface{})
 	switch value := i.(type) {
 	case string:
 		return n.FormValue(value, options)
+	case []string:
+		return n.formValues(value, options)
 	case []interface{}:
 		n.Val = []int64{}
 
@@ -90,6 +97,11 @@ func (n *Int64Slice) JSONValue(path string, i interface{}, options interface{})
 			return errorsInSlice
 		}
 
+		var err Errorable
+		if n.Val, err = constrainValues(n.Val, sliceOpts); err != nil {
+			return err
+		}
+
 		if sliceOpts.MinLengthPresent && len(n.Val) < sliceOpts.MinLength {
 			return ErrMinLength
 		}
@@ -112,6 +124,11 @@ func (n *Int64Slice) JSONValue(path string, i interface{}, options interface{})
 }
 
 func (i *Int64Slice) FormValue(value string, options interface{}) Errorable {
+	return i.formValues([]string{value}, options)
+}
+
+// formValues decodes the values of a form key, repeated keys giving several values.
+func (i *Int64Slice) formValues(values []string, options interface{}) Errorable {
 	var tempI Int64
 
 	opts := options.(*IntSliceOptions)
@@ -119,12 +136,18 @@ func (i *Int64Slice) FormValue(value string, options interface{}) Errorable {
 	sliceOpts := opts.SliceOptions
 
 	i.Val = []int64{}
+	i.sqlFormat = sliceOpts.SQLFormat
 	i.Present = true
 	i.Null = false
 
-	value = strings.TrimSpace(value)
+	values = append([]string(nil), values...)
+	blank := true
+	for j, value := range values {
+		values[j] = strings.TrimSpace(value)
+		blank = blank && values[j] == ""
+	}
 
-	if value == "" {
+	if blank {
 		if sliceOpts.DiscardBlank {
 			i.Present = false
 			return nil
@@ -134,7 +157,10 @@ func (i *Int64Slice) FormValue(value string, options interface{}) Errorable {
 		return ErrBlank
 	}
 
-	strs := strings.Split(value, ",")
+	strs, err := sliceOpts.split(values)
+	if err != nil {
+		return err
+	}
 
 	if sliceOpts.MinLengthPresent && len(strs) < sliceOpts.MinLength {
 		return ErrMinLength
@@ -159,6 +185,10 @@ func (i *Int64Slice) FormValue(value string, options interface{}) Errorable {
 		return errorsInSlice
 	}
 
+	if i.Val, err = constrainValues(i.Val, sliceOpts); err != nil {
+		return err
+	}
+
 	if sliceOpts.MinLengthPresent && len(i.Val) < sliceOpts.MinLength {
 		return ErrMinLength
 	}
@@ -180,6 +210,47 @@ func (i *Int64Slice) FormValue(value string, options interface{}) Errorable {
 	return nil
 }
 
+// Value stores the slice as a Postgres array literal, eg "{1,2,3}", or as a JSON array when the
+// field's meta_sql_format is "json".
+func (s Int64Slice) Value() (driver.Value, error) {
+	if s.Null || (!s.Present && s.Val == nil) {
+		return nil, nil
+	}
+	values := make([]driver.Value, len(s.Val))
+	for i, v := range s.Val {
+		values[i] = v
+	}
+	return encodeSQLSlice(values, s.sqlFormat)
+}
+
+// WithSQLFormat returns a copy of s that Value stores in format, SQLFormatArray or SQLFormatJSON.
+func (s Int64Slice) WithSQLFormat(format string) Int64Slice {
+	s.sqlFormat = parseSQLFormat(format)
+	return s
+}
+
+// Scan reads Postgres integer arrays, eg "{1,2,3}", and JSON arrays of integers. The SQL format of s
+// is kept.
+func (s *Int64Slice) Scan(src interface{}) error {
+	if src == nil {
+		*s = Int64Slice{Nullity: Nullity{true}, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+		return nil
+	}
+
+	elems, err := scanSliceStrings(src)
+	if err != nil {
+		return err
+	}
+	val := make([]int64, len(elems))
+	for i, elem := range elems {
+		if val[i], err = strconv.ParseInt(elem, 10, 64); err != nil {
+			return err
+		}
+	}
+	*s = Int64Slice{Val: val, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+	return nil
+}
+
 func (s Int64Slice) MarshalJSON() ([]byte, error) {
 	if len(s.Val) > 0 {
 		return MetaJson.Marshal(s.Val)
@@ -182,3 +182,60 @@ func TestIntSliceLength(t *testing.T) {
 	assertEqual(t, e, ErrorHash{"a": ErrMaxLength})
 	assertEqual(t, len(inputs.A.Val), 0)
 }
+
+func TestInt64SliceScan(t *testing.T) {
+	var s Int64Slice
+
+	err := s.Scan([]byte("{1,-2,3}"))
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []int64{1, -2, 3})
+	assertEqual(t, s.Present, true)
+
+	err = s.Scan("{}")
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []int64{})
+
+	err = s.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, s, Int64Slice{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = s.Scan("{1,x}")
+	assert(t, err != nil)
+
+	err = s.Scan("{1,NULL}")
+	assert(t, err != nil)
+}
+
+func TestIntSliceSeparatorAndRepeatedKeys(t *testing.T) {
+	var inputs struct {
+		A Int64Slice `meta_separator:"|"`
+		B Int64Slice
+	}
+	d := NewDecoder(&inputs)
+
+	values := url.Values{"a": {"1|2", " 3 "}, "b": {"4", "5,6"}}
+	e := d.DecodeValues(&inputs, values)
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []int64{1, 2, 3})
+	assertEqual(t, inputs.B.Val, []int64{4, 5, 6})
+	assertEqual(t, values["a"], []string{"1|2", " 3 "})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"1,2"}, "b": {"7", "x"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{ErrInt}, "b": ErrorSlice{nil, ErrInt}})
+}
+
+func TestIntSliceUniqueAndSorted(t *testing.T) {
+	var inputs struct {
+		A Int64Slice `meta_unique:"true"`
+		B Int64Slice `meta_sorted:"desc" meta_normalize:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"1,2,2"}, "b": {"1,3,2"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{nil, nil, ErrUnique}})
+	assertEqual(t, inputs.B.Val, []int64{3, 2, 1})
+
+	e = d.DecodeJSON(&inputs, []byte(`{"a":[3,2,1],"b":[10,20]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.B.Val, []int64{20, 10})
+}
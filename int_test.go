@@ -648,3 +648,53 @@ func TestOptionalNullUintOmitted(t *testing.T) {
 	assertEqual(t, inputs.A.Null, false)
 	assertEqual(t, inputs.A.Val, uint64(0))
 }
+
+func TestInt64Scan(t *testing.T) {
+	var i Int64
+
+	err := i.Scan(int64(-12))
+	assertEqual(t, err, nil)
+	assertEqual(t, i, NewInt64(-12))
+
+	err = i.Scan([]byte("42"))
+	assertEqual(t, err, nil)
+	assertEqual(t, i.Val, int64(42))
+
+	err = i.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, i, Int64{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = i.Scan("4.2")
+	assert(t, err != nil)
+
+	err = i.Scan(4.2)
+	assert(t, err != nil)
+}
+
+func TestUint64Scan(t *testing.T) {
+	var u Uint64
+
+	// values round-trip through Value, including those stored as negative int64s
+	for _, n := range []uint64{0, math.MaxInt64, 1<<63 + 5, math.MaxUint64} {
+		v, err := NewUint64(n).Value()
+		assertEqual(t, err, nil, n)
+		err = u.Scan(v)
+		assertEqual(t, err, nil, n)
+		assertEqual(t, u, NewUint64(n), n)
+	}
+
+	err := u.Scan(uint64(math.MaxUint64))
+	assertEqual(t, err, nil)
+	assertEqual(t, u.Val, uint64(math.MaxUint64))
+
+	err = u.Scan("7")
+	assertEqual(t, err, nil)
+	assertEqual(t, u.Val, uint64(7))
+
+	err = u.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, u.Null, true)
+
+	err = u.Scan("-7")
+	assert(t, err != nil)
+}
@@ -0,0 +1,347 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+//
+// IP, Prefix
+//
+
+type IP struct {
+	Val netip.Addr
+	Nullity
+	Presence
+	Path string
+}
+
+type Prefix struct {
+	Val netip.Prefix
+	Nullity
+	Presence
+	Path string
+}
+
+type IPFamily string
+
+const (
+	FamilyV4 IPFamily = "v4"
+	FamilyV6 IPFamily = "v6"
+)
+
+// IPOptions is shared by IP and Prefix. For a Prefix the checks apply to its (masked) address,
+// and Within requires the whole prefix to fit inside one of the configured prefixes.
+type IPOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// Family restricts the address family. IPv4-mapped IPv6 addresses count as v4.
+	// Configured via meta_family tag, eg `meta_family:"v4"`.
+	Family IPFamily
+	// DisallowPrivate rejects private, loopback, link-local and unspecified addresses.
+	// Configured via meta_disallow_private tag.
+	DisallowPrivate bool
+	// DisallowMulticast rejects multicast addresses.
+	// Configured via meta_disallow_multicast tag.
+	DisallowMulticast bool
+	// Within requires the value to be contained in one of these prefixes.
+	// Configured via meta_within tag, eg `meta_within:"10.0.0.0/8,192.168.0.0/16"`.
+	Within []netip.Prefix
+}
+
+func NewIP(addr netip.Addr) IP {
+	return IP{addr, Nullity{false}, Presence{true}, ""}
+}
+
+func NewPrefix(p netip.Prefix) Prefix {
+	return Prefix{p, Nullity{false}, Presence{true}, ""}
+}
+
+func (ip *IP) ParseOptions(tag reflect.StructTag) interface{} {
+	return parseIPOptions(tag)
+}
+
+func (p *Prefix) ParseOptions(tag reflect.StructTag) interface{} {
+	return parseIPOptions(tag)
+}
+
+func parseIPOptions(tag reflect.StructTag) *IPOptions {
+	opts := &IPOptions{
+		Required:          tag.Get("meta_required") == "true",
+		DiscardBlank:      tag.Get("meta_discard_blank") != "false",
+		Null:              tag.Get("meta_null") == "true",
+		DisallowPrivate:   tag.Get("meta_disallow_private") == "true",
+		DisallowMulticast: tag.Get("meta_disallow_multicast") == "true",
+	}
+
+	switch family := IPFamily(tag.Get("meta_family")); family {
+	case "":
+	case FamilyV4, FamilyV6:
+		opts.Family = family
+	default:
+		panic("meta_family must be v4 or v6, got " + string(family))
+	}
+
+	if within := tag.Get("meta_within"); within != "" {
+		for _, s := range strings.Split(within, ",") {
+			p, err := netip.ParsePrefix(strings.TrimSpace(s))
+			if err != nil {
+				panic(err.Error())
+			}
+
+			opts.Within = append(opts.Within, p.Masked())
+		}
+	}
+
+	return opts
+}
+
+func (ip *IP) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	ip.Path = path
+	if i == nil {
+		return ip.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return ip.FormValue(value, options)
+	case netip.Addr:
+		return ip.validateValue(value, options)
+	}
+	return ErrIP
+}
+
+func (ip *IP) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*IPOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			ip.Present = true
+			ip.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			ip.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return ErrIP
+	}
+	return ip.validateValue(addr, options)
+}
+
+func (ip *IP) validateValue(addr netip.Addr, options interface{}) Errorable {
+	opts := options.(*IPOptions)
+
+	if err := opts.assertAddr(addr); err != nil {
+		return err
+	}
+
+	if len(opts.Within) > 0 {
+		found := false
+		for _, p := range opts.Within {
+			if p.Contains(addr.Unmap()) {
+				found = true
+			}
+		}
+		if !found {
+			return ErrIn
+		}
+	}
+
+	ip.Val = addr
+	ip.Present = true
+	return nil
+}
+
+func (p *Prefix) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	p.Path = path
+	if i == nil {
+		return p.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return p.FormValue(value, options)
+	case netip.Prefix:
+		return p.validateValue(value, options)
+	}
+	return ErrPrefix
+}
+
+func (p *Prefix) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*IPOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			p.Present = true
+			p.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			p.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return ErrPrefix
+	}
+	return p.validateValue(prefix, options)
+}
+
+// validateValue stores the masked prefix, so "10.1.2.3/8" is kept as "10.0.0.0/8".
+func (p *Prefix) validateValue(prefix netip.Prefix, options interface{}) Errorable {
+	opts := options.(*IPOptions)
+
+	prefix = prefix.Masked()
+	if err := opts.assertAddr(prefix.Addr()); err != nil {
+		return err
+	}
+	if opts.DisallowPrivate && overlapsPrivate(prefix) {
+		return ErrPrivateAddress
+	}
+
+	if len(opts.Within) > 0 {
+		found := false
+		for _, w := range opts.Within {
+			if w.Bits() <= prefix.Bits() && w.Contains(prefix.Addr()) {
+				found = true
+			}
+		}
+		if !found {
+			return ErrIn
+		}
+	}
+
+	p.Val = prefix
+	p.Present = true
+	return nil
+}
+
+func (opts *IPOptions) assertAddr(addr netip.Addr) Errorable {
+	unmapped := addr.Unmap()
+
+	if opts.Family == FamilyV4 && !unmapped.Is4() {
+		return ErrIPFamily
+	}
+	if opts.Family == FamilyV6 && unmapped.Is4() {
+		return ErrIPFamily
+	}
+	if opts.DisallowPrivate && isPrivateAddr(unmapped) {
+		return ErrPrivateAddress
+	}
+	if opts.DisallowMulticast && unmapped.IsMulticast() {
+		return ErrMulticastAddress
+	}
+	return nil
+}
+
+// privatePrefixes are the ranges matched by isPrivateAddr, and their IPv4-mapped forms.
+var privatePrefixes = func() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, s := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "127.0.0.0/8", "169.254.0.0/16", "224.0.0.0/24", "0.0.0.0/32"} {
+		p := netip.MustParsePrefix(s)
+		mapped := netip.AddrFrom16(p.Addr().As16())
+		prefixes = append(prefixes, p, netip.PrefixFrom(mapped, p.Bits()+96))
+	}
+	for _, s := range []string{"fc00::/7", "::1/128", "fe80::/10", "::/128"} {
+		prefixes = append(prefixes, netip.MustParsePrefix(s))
+	}
+	// link-local and interface-local multicast, with any flags
+	for flags := 0; flags < 16; flags++ {
+		for _, scope := range []byte{0x1, 0x2} {
+			prefixes = append(prefixes, netip.PrefixFrom(netip.AddrFrom16([16]byte{0xff, byte(flags<<4) | scope}), 16))
+		}
+	}
+	return prefixes
+}()
+
+// overlapsPrivate reports whether prefix contains any address rejected by isPrivateAddr, eg
+// "172.0.0.0/8" which contains 172.16.0.0/12.
+func overlapsPrivate(prefix netip.Prefix) bool {
+	for _, p := range privatePrefixes {
+		if prefix.Overlaps(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ip IP) Value() (driver.Value, error) {
+	if ip.Present && !ip.Null {
+		return ip.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (p Prefix) Value() (driver.Value, error) {
+	if p.Present && !p.Null {
+		return p.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (ip IP) MarshalJSON() ([]byte, error) {
+	if ip.Present && !ip.Null {
+		return MetaJson.Marshal(ip.Val.String())
+	}
+	return nullString, nil
+}
+
+func (ip *IP) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		ip.Nullity = Nullity{true}
+		return nil
+	}
+
+	err := MetaJson.Unmarshal(b, &ip.Val)
+	if err != nil {
+		return err
+	}
+	ip.Presence = Presence{true}
+	ip.Nullity = Nullity{false}
+	return nil
+}
+
+func (p Prefix) MarshalJSON() ([]byte, error) {
+	if p.Present && !p.Null {
+		return MetaJson.Marshal(p.Val.String())
+	}
+	return nullString, nil
+}
+
+func (p *Prefix) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		p.Nullity = Nullity{true}
+		return nil
+	}
+
+	err := MetaJson.Unmarshal(b, &p.Val)
+	if err != nil {
+		return err
+	}
+	p.Presence = Presence{true}
+	p.Nullity = Nullity{false}
+	return nil
+}
@@ -0,0 +1,170 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/netip"
+	"net/url"
+	"testing"
+)
+
+type withIP struct {
+	A IP     `meta_required:"true"`
+	B Prefix `meta_required:"true"`
+}
+
+var withIPDecoder = NewDecoder(&withIP{})
+
+func TestIPSuccess(t *testing.T) {
+	var inputs withIP
+
+	e := withIPDecoder.DecodeValues(&inputs, url.Values{"a": {"192.168.1.1"}, "b": {"10.0.0.0/8"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, netip.MustParseAddr("192.168.1.1"))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.B.Val, netip.MustParsePrefix("10.0.0.0/8"))
+	assertEqual(t, inputs.B.Present, true)
+
+	inputs = withIP{}
+	e = withIPDecoder.DecodeJSON(&inputs, []byte(`{"a":"2001:db8::1","b":"2001:db8::1/32"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, netip.MustParseAddr("2001:db8::1"))
+	assertEqual(t, inputs.B.Val, netip.MustParsePrefix("2001:db8::/32"))
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestIPFailure(t *testing.T) {
+	var inputs withIP
+
+	e := withIPDecoder.DecodeValues(&inputs, url.Values{"a": {"256.0.0.1"}, "b": {"10.0.0.0"}})
+	assertEqual(t, e, ErrorHash{"a": ErrIP, "b": ErrPrefix})
+
+	e = withIPDecoder.DecodeJSON(&inputs, []byte(`{"a":1,"b":true}`))
+	assertEqual(t, e, ErrorHash{"a": ErrIP, "b": ErrPrefix})
+
+	e = withIPDecoder.DecodeJSON(&inputs, []byte(`{"a":"","b":null}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank, "b": ErrBlank})
+
+	e = withIPDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired, "b": ErrRequired})
+}
+
+func TestIPFamily(t *testing.T) {
+	var inputs struct {
+		A IP     `meta_family:"v4"`
+		B Prefix `meta_family:"v6"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"1.2.3.4"}, "b": {"2001:db8::/32"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"::ffff:1.2.3.4"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2001:db8::1"}, "b": {"10.0.0.0/8"}})
+	assertEqual(t, e, ErrorHash{"a": ErrIPFamily, "b": ErrIPFamily})
+}
+
+func TestIPDisallowRanges(t *testing.T) {
+	var inputs struct {
+		A IP     `meta_disallow_private:"true" meta_disallow_multicast:"true"`
+		B Prefix `meta_disallow_private:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	for _, bad := range []string{"10.0.0.1", "127.0.0.1", "::1", "fe80::1", "0.0.0.0", "172.16.5.4"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrPrivateAddress}, bad)
+	}
+
+	for _, bad := range []string{"239.1.2.3", "ff0e::1"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrMulticastAddress}, bad)
+	}
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"8.8.8.8"}, "b": {"192.168.0.0/24"}})
+	assertEqual(t, e, ErrorHash{"b": ErrPrivateAddress})
+
+	for _, bad := range []string{"172.0.0.0/8", "128.0.0.0/1", "0.0.0.0/0", "::/0", "fe00::/8", "::ffff:0.0.0.0/96", "ff00::/8"} {
+		e := d.DecodeValues(&inputs, url.Values{"b": {bad}})
+		assertEqual(t, e, ErrorHash{"b": ErrPrivateAddress}, bad)
+	}
+
+	for _, good := range []string{"8.0.0.0/8", "172.32.0.0/11", "2001:db8::/32", "::ffff:8.8.0.0/112"} {
+		e := d.DecodeValues(&inputs, url.Values{"b": {good}})
+		assertEqual(t, e, ErrorHash(nil), good)
+	}
+}
+
+func TestIPWithin(t *testing.T) {
+	var inputs struct {
+		A IP     `meta_within:"10.0.0.0/8, 2001:db8::/32"`
+		B Prefix `meta_within:"10.0.0.0/8"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"10.20.30.40"}, "b": {"10.1.0.0/16"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2001:db8::5"}, "b": {"10.0.0.0/8"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"11.0.0.1"}, "b": {"10.0.0.0/7"}})
+	assertEqual(t, e, ErrorHash{"a": ErrIn, "b": ErrIn})
+}
+
+func TestPrefixMasked(t *testing.T) {
+	var inputs withIP
+
+	e := withIPDecoder.DecodeValues(&inputs, url.Values{"a": {"1.1.1.1"}, "b": {"10.1.2.3/8"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.B.Val, netip.MustParsePrefix("10.0.0.0/8"))
+}
+
+func TestIPAllowlistSlice(t *testing.T) {
+	var inputs struct {
+		Allow []Prefix `meta_required:"true" meta_max_length:"2" meta_element_family:"v4"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"allow":["10.0.0.0/8","192.168.1.0/24"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, len(inputs.Allow), 2)
+	assertEqual(t, inputs.Allow[1].Val, netip.MustParsePrefix("192.168.1.0/24"))
+
+	e = d.DecodeValues(&inputs, url.Values{"allow.0": {"10.0.0.0/8"}, "allow.1": {"::/0"}})
+	assertEqual(t, e, ErrorHash{"allow": ErrorSlice{nil, ErrIPFamily}})
+
+	e = d.DecodeJSON(&inputs, []byte(`{"allow":["1.0.0.0/8","2.0.0.0/8","3.0.0.0/8"]}`))
+	assertEqual(t, e, ErrorHash{"allow": ErrMaxLength})
+}
+
+func TestIPJSON(t *testing.T) {
+	obj := struct {
+		A IP
+		B Prefix
+		C IP
+	}{
+		A: NewIP(netip.MustParseAddr("1.2.3.4")),
+		B: NewPrefix(netip.MustParsePrefix("10.0.0.0/8")),
+	}
+
+	bs, err := json.Marshal(obj)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"A":"1.2.3.4","B":"10.0.0.0/8","C":null}`)
+
+	var decoded struct {
+		A IP
+		B Prefix
+		C IP
+	}
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.A.Val, obj.A.Val)
+	assertEqual(t, decoded.B.Val, obj.B.Val)
+	assertEqual(t, decoded.C.Null, true)
+
+	v, err := obj.B.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "10.0.0.0/8")
+}
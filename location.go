@@ -0,0 +1,269 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// Location
+//
+
+type Location struct {
+	Val *time.Location
+	Nullity
+	Presence
+	Path string
+}
+
+type LocationOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// AllowOffset accepts fixed UTC offsets like "+05:30", "-0800" or "UTC+2" in addition to zone names.
+	// Configured via meta_allow_offset tag.
+	AllowOffset bool
+}
+
+// LocationAliases maps lower-cased alternative names to IANA zone names. It is consulted before
+// loading a zone, so deprecated names and common abbreviations resolve to a canonical zone.
+// Abbreviations that are zones themselves, like the fixed-offset "EST" and "MST", are left out so
+// they keep loading as such, and so are ambiguous ones like "CST", which is also China and Cuba
+// Standard Time. Applications can add their own entries at init time.
+var LocationAliases = map[string]string{
+	"utc":                  "UTC",
+	"gmt":                  "UTC",
+	"z":                    "UTC",
+	"zulu":                 "UTC",
+	"eastern":              "America/New_York",
+	"edt":                  "America/New_York",
+	"central":              "America/Chicago",
+	"cdt":                  "America/Chicago",
+	"mountain":             "America/Denver",
+	"mdt":                  "America/Denver",
+	"pacific":              "America/Los_Angeles",
+	"pst":                  "America/Los_Angeles",
+	"pdt":                  "America/Los_Angeles",
+	"asia/calcutta":        "Asia/Kolkata",
+	"asia/saigon":          "Asia/Ho_Chi_Minh",
+	"asia/katmandu":        "Asia/Kathmandu",
+	"asia/rangoon":         "Asia/Yangon",
+	"america/buenos_aires": "America/Argentina/Buenos_Aires",
+	"america/indianapolis": "America/Indiana/Indianapolis",
+	"pacific/samoa":        "Pacific/Pago_Pago",
+}
+
+var (
+	fixedOffsetRegex   = regexp.MustCompile(`^(?i:utc|gmt)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	gmtOffsetZoneRegex = regexp.MustCompile(`^(gmt|utc)([+-]\d+)?$`)
+)
+
+func NewLocation(loc *time.Location) Location {
+	return Location{loc, Nullity{false}, Presence{true}, ""}
+}
+
+func (l *Location) ParseOptions(tag reflect.StructTag) interface{} {
+	return &LocationOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		AllowOffset:  tag.Get("meta_allow_offset") == "true",
+	}
+}
+
+func (l *Location) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	l.Path = path
+	if i == nil {
+		return l.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return l.FormValue(value, options)
+	case *time.Location:
+		if value == nil {
+			return l.FormValue("", options)
+		}
+		// validated by name, like input strings, so "Local" and offsets follow the same rules
+		if value.String() == "" {
+			return ErrLocation
+		}
+		return l.FormValue(value.String(), options)
+	}
+	return ErrLocation
+}
+
+func (l *Location) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*LocationOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			l.Present = true
+			l.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			l.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	if loc, ok := lookupLocation(value); ok {
+		l.Val = loc
+		l.Present = true
+		return nil
+	}
+
+	if loc, ok := parseFixedOffset(value); ok {
+		if !opts.AllowOffset {
+			return ErrLocationOffset
+		}
+		l.Val = loc
+		l.Present = true
+		return nil
+	}
+
+	return ErrLocation
+}
+
+// Value stores the zone name, or the offset for fixed offsets.
+func (l Location) Value() (driver.Value, error) {
+	if l.Present && !l.Null && l.Val != nil {
+		return l.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (l Location) MarshalJSON() ([]byte, error) {
+	if l.Present && !l.Null && l.Val != nil {
+		return MetaJson.Marshal(l.Val.String())
+	}
+	return nullString, nil
+}
+
+func (l *Location) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		l.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	loc, ok := lookupLocation(s)
+	if !ok {
+		if loc, ok = parseFixedOffset(s); !ok {
+			return fmt.Errorf("meta: unknown time zone %q", s)
+		}
+	}
+
+	l.Val = loc
+	l.Presence = Presence{true}
+	l.Nullity = Nullity{false}
+	return nil
+}
+
+// loadedLocations caches the zones found by loadLocation, by name. Names that aren't zones
+// aren't cached, since they come from the input.
+var loadedLocations sync.Map
+
+// loadLocation is time.LoadLocation, which reads the zone database on every call, with a cache.
+func loadLocation(name string) (*time.Location, bool) {
+	if loc, ok := loadedLocations.Load(name); ok {
+		return loc.(*time.Location), true
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	loadedLocations.Store(name, loc)
+	return loc, true
+}
+
+// lookupLocation loads an IANA zone by name, resolving LocationAliases and tolerating
+// wrong case in names like "america/new_york". "Local" and "" are rejected since they
+// depend on the server rather than the input.
+func lookupLocation(name string) (*time.Location, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, false
+	}
+
+	if alias, ok := LocationAliases[strings.ToLower(name)]; ok {
+		name = alias
+	}
+
+	for _, candidate := range []string{name, canonicalZoneCase(name), strings.ToUpper(name)} {
+		if loc, ok := loadLocation(candidate); ok {
+			return loc, true
+		}
+	}
+	return nil, false
+}
+
+// canonicalZoneCase capitalizes each word of a zone name, eg "america/new_york" -> "America/New_York".
+// Two letter areas and GMT/UTC offsets are upper-cased, eg "us/pacific" -> "US/Pacific" and
+// "etc/gmt+5" -> "Etc/GMT+5". Names with lower-case words, like "America/Port-au-Prince", still need
+// their exact case.
+func canonicalZoneCase(name string) string {
+	parts := strings.Split(strings.ToLower(name), "/")
+	for i, part := range parts {
+		if (i == 0 && len(part) <= 2) || gmtOffsetZoneRegex.MatchString(part) {
+			parts[i] = strings.ToUpper(part)
+			continue
+		}
+		parts[i] = titleWords(part, "_-")
+	}
+	return strings.Join(parts, "/")
+}
+
+// titleWords upper-cases the first letter of s and every letter following one of separators.
+func titleWords(s string, separators string) string {
+	b := []byte(s)
+	upper := true
+	for i, c := range b {
+		if upper && 'a' <= c && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+		upper = strings.IndexByte(separators, c) >= 0
+	}
+	return string(b)
+}
+
+func parseFixedOffset(value string) (*time.Location, bool) {
+	m := fixedOffsetRegex.FindStringSubmatch(value)
+	if m == nil {
+		return nil, false
+	}
+
+	hours, _ := strconv.Atoi(m[2])
+	minutes := 0
+	if m[3] != "" {
+		minutes, _ = strconv.Atoi(m[3])
+	}
+	if hours > 14 || minutes > 59 {
+		return nil, false
+	}
+
+	offset := hours*3600 + minutes*60
+	if m[1] == "-" {
+		offset = -offset
+	}
+	return time.FixedZone(fmt.Sprintf("%s%02d:%02d", m[1], hours, minutes), offset), true
+}
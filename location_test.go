@@ -0,0 +1,169 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withLocation struct {
+	A Location `meta_required:"true"`
+}
+
+var withLocationDecoder = NewDecoder(&withLocation{})
+
+func TestLocationSuccess(t *testing.T) {
+	for input, expected := range map[string]string{
+		"America/New_York":               "America/New_York",
+		"america/new_york":               "America/New_York",
+		" Europe/Paris ":                 "Europe/Paris",
+		"UTC":                            "UTC",
+		"utc":                            "UTC",
+		"GMT":                            "UTC",
+		"us/pacific":                     "US/Pacific",
+		"etc/gmt+5":                      "Etc/GMT+5",
+		"EST5EDT":                        "EST5EDT",
+		"PST":                            "America/Los_Angeles",
+		"EST":                            "EST",
+		"mst":                            "MST",
+		"EDT":                            "America/New_York",
+		"Asia/Calcutta":                  "Asia/Kolkata",
+		"america/argentina/buenos_aires": "America/Argentina/Buenos_Aires",
+	} {
+		var inputs withLocation
+		e := withLocationDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Present, true, input)
+		if inputs.A.Val != nil {
+			assertEqual(t, inputs.A.Val.String(), expected, input)
+		}
+	}
+
+	var inputs withLocation
+	e := withLocationDecoder.DecodeJSON(&inputs, []byte(`{"a":"Asia/Tokyo"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val.String(), "Asia/Tokyo")
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestLocationInvalid(t *testing.T) {
+	var inputs withLocation
+
+	for _, bad := range []string{"Mars/Olympus", "Local", "local", "../../etc/passwd", "/etc/localtime", "New York"} {
+		e := withLocationDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrLocation}, bad)
+	}
+
+	for _, offset := range []string{"+05:30", "-0800", "UTC+2", "gmt-03"} {
+		e := withLocationDecoder.DecodeValues(&inputs, url.Values{"a": {offset}})
+		assertEqual(t, e, ErrorHash{"a": ErrLocationOffset}, offset)
+	}
+
+	e := withLocationDecoder.DecodeJSON(&inputs, []byte(`{"a":5}`))
+	assertEqual(t, e, ErrorHash{"a": ErrLocation})
+
+	e = withLocationDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withLocationDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+
+	var nilLocation *time.Location
+	inputs = withLocation{}
+	err := inputs.A.JSONValue("a", nilLocation, withLocationDecoder.Fields[0].Options)
+	assertEqual(t, err, ErrBlank)
+	assertEqual(t, inputs.A.Val, (*time.Location)(nil))
+
+	// a *time.Location is checked like its name
+	for loc, expected := range map[*time.Location]Errorable{
+		time.Local:                        ErrLocation,
+		time.FixedZone("", 3600):          ErrLocation,
+		time.FixedZone("+01:00", 3600):    ErrLocationOffset,
+		time.FixedZone("Nowhere/Land", 0): ErrLocation,
+		time.UTC:                          nil,
+	} {
+		inputs = withLocation{}
+		err = inputs.A.JSONValue("a", loc, withLocationDecoder.Fields[0].Options)
+		assertEqual(t, err, expected, loc.String())
+		if expected == nil {
+			assertEqual(t, inputs.A.Val.String(), loc.String())
+		}
+	}
+
+	for _, abbreviation := range []string{"CST", "cst"} {
+		e = withLocationDecoder.DecodeValues(&inputs, url.Values{"a": {abbreviation}})
+		assertEqual(t, e, ErrorHash{"a": ErrLocation}, abbreviation)
+	}
+}
+
+func TestLocationAllowOffset(t *testing.T) {
+	var inputs struct {
+		A Location `meta_allow_offset:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	for input, expected := range map[string]int{
+		"+05:30": 5*3600 + 30*60,
+		"-0800":  -8 * 3600,
+		"UTC+2":  2 * 3600,
+		"GMT-3":  -3 * 3600,
+	} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, inputs.A.Val).Zone()
+		assertEqual(t, offset, expected, input)
+	}
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"+15:00"}})
+	assertEqual(t, e, ErrorHash{"a": ErrLocation})
+
+	err := inputs.A.JSONValue("a", time.FixedZone("+01:00", 3600), d.Fields[0].Options)
+	assertEqual(t, err, nil)
+	_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, inputs.A.Val).Zone()
+	assertEqual(t, offset, 3600)
+}
+
+func TestLocationAliases(t *testing.T) {
+	LocationAliases["hq"] = "Europe/Berlin"
+	defer delete(LocationAliases, "hq")
+
+	var inputs withLocation
+	e := withLocationDecoder.DecodeValues(&inputs, url.Values{"a": {"HQ"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val.String(), "Europe/Berlin")
+}
+
+func TestLocationWithTimeLocationField(t *testing.T) {
+	var inputs struct {
+		TimeZone Location
+		At       Time `meta_format:"DateTime" meta_location_field:"time_zone"`
+	}
+
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"time_zone": {"pacific"}, "at": {"2024-01-15 09:00:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.TimeZone.Val.String(), "America/Los_Angeles")
+	assert(t, inputs.At.Val.Equal(time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)))
+}
+
+func TestLocationJSON(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	l := NewLocation(tokyo)
+
+	bs, err := json.Marshal(l)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"Asia/Tokyo"`)
+
+	var decoded Location
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val.String(), "Asia/Tokyo")
+	assertEqual(t, decoded.Present, true)
+
+	err = json.Unmarshal([]byte(`"Nowhere/Land"`), &decoded)
+	assert(t, err != nil)
+
+	v, err := l.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "Asia/Tokyo")
+}
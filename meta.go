@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 type Valuer interface {
@@ -22,6 +24,12 @@ type Optionaler interface {
 	Optional() bool
 }
 
+// sourceOptions are parsed options that depend on other values of the input being decoded.
+// withSource is called with the source of the enclosing struct and returns the options to use.
+type sourceOptions interface {
+	withSource(src source) interface{}
+}
+
 type decoderFieldCategory int
 
 const (
@@ -44,6 +52,34 @@ type SliceOptions struct {
 	MinLength        int
 	MaxLengthPresent bool
 	MaxLength        int
+	// SQLFormat is how Value stores StringSlice and Int64Slice, SQLFormatArray or SQLFormatJSON.
+	// Configured via meta_sql_format tag, "array", "json" or "jsonb".
+	// Default: SQLFormatArray
+	SQLFormat string
+	// Separator splits form input into elements. " " splits on runs of whitespace.
+	// Configured via meta_separator tag, eg "|", ";" or "whitespace".
+	// Default: ","
+	Separator string
+	// Quoted lets elements be double-quoted, CSV-style, so they can contain the separator, eg
+	// `a,"b,c"`. A quote inside a quoted element is written twice.
+	// Configured via meta_quoted tag.
+	// Default: false
+	Quoted bool
+	// Unique rejects duplicate elements, compared case-insensitively for strings when
+	// UniqueCaseInsensitive is set.
+	// Configured via meta_unique tag, "true" or "case_insensitive".
+	Unique                bool
+	UniqueCaseInsensitive bool
+	// Sorted requires the elements to be in "asc" or "desc" order.
+	// Configured via meta_sorted tag.
+	Sorted string
+	// Key is the meta name of the field that Unique and Sorted compare for slices of structs.
+	// Configured via meta_key tag.
+	Key string
+	// Normalize drops duplicates and sorts the elements instead of reporting errors.
+	// Configured via meta_normalize tag.
+	// Default: false
+	Normalize bool
 }
 
 func ParseSliceOptions(tag reflect.StructTag) *SliceOptions {
@@ -52,6 +88,18 @@ func ParseSliceOptions(tag reflect.StructTag) *SliceOptions {
 	sliceOpts.Blank = tag.Get("meta_blank") == "true"
 	sliceOpts.Null = tag.Get("meta_null") == "true"
 	sliceOpts.DiscardBlank = tag.Get("meta_discard_blank") != "false"
+	sliceOpts.SQLFormat = parseSQLFormat(tag.Get("meta_sql_format"))
+	sliceOpts.Quoted = tag.Get("meta_quoted") == "true"
+	parseSliceConstraints(sliceOpts, tag)
+
+	switch separator := tag.Get("meta_separator"); separator {
+	case "":
+		sliceOpts.Separator = ","
+	case "whitespace", "space":
+		sliceOpts.Separator = " "
+	default:
+		sliceOpts.Separator = separator
+	}
 
 	if minLengthString := tag.Get("meta_min_length"); minLengthString != "" {
 		minLength, err := strconv.ParseInt(minLengthString, 10, 0)
@@ -76,6 +124,103 @@ func ParseSliceOptions(tag reflect.StructTag) *SliceOptions {
 	return sliceOpts
 }
 
+// split splits the form values of a slice into elements. Repeated keys, eg ?tag=a&tag=b, give
+// several values whose elements are concatenated. It returns ErrQuote for an unterminated quote
+// or text after a closing quote.
+func (opts *SliceOptions) split(values []string) ([]string, Errorable) {
+	var elems []string
+	for _, value := range values {
+		var parts []string
+		switch {
+		case opts.Quoted:
+			var ok bool
+			if parts, ok = splitQuoted(value, opts.Separator); !ok {
+				return nil, ErrQuote
+			}
+		case opts.Separator == " ":
+			parts = strings.Fields(value)
+		default:
+			parts = strings.Split(value, opts.Separator)
+		}
+		elems = append(elems, parts...)
+	}
+	return elems, nil
+}
+
+// splitQuoted splits value on sep, " " meaning whitespace, honoring double-quoted elements.
+func splitQuoted(value, sep string) ([]string, bool) {
+	whitespace := sep == " "
+	isBlank := func(c byte) bool { return c == ' ' || c == '\t' }
+
+	var elems []string
+	i := 0
+	for {
+		if whitespace {
+			i += len(value[i:]) - len(strings.TrimLeftFunc(value[i:], unicode.IsSpace))
+			if i == len(value) {
+				return elems, true
+			}
+		}
+
+		j := i
+		for j < len(value) && isBlank(value[j]) {
+			j++
+		}
+
+		if j < len(value) && value[j] == '"' {
+			var b strings.Builder
+			for j++; ; j++ {
+				if j >= len(value) {
+					return nil, false
+				}
+				if value[j] == '"' {
+					if j+1 < len(value) && value[j+1] == '"' {
+						b.WriteByte('"')
+						j++
+						continue
+					}
+					break
+				}
+				b.WriteByte(value[j])
+			}
+			elems = append(elems, b.String())
+
+			i = j + 1
+			if whitespace {
+				if i < len(value) && !unicode.IsSpace(rune(value[i])) {
+					return nil, false
+				}
+				continue
+			}
+			for i < len(value) && isBlank(value[i]) {
+				i++
+			}
+			if i < len(value) && !strings.HasPrefix(value[i:], sep) {
+				return nil, false
+			}
+		} else {
+			end := len(value)
+			if whitespace {
+				if k := strings.IndexFunc(value[i:], unicode.IsSpace); k >= 0 {
+					end = i + k
+				}
+			} else if k := strings.Index(value[i:], sep); k >= 0 {
+				end = i + k
+			}
+			elems = append(elems, value[i:end])
+			i = end
+			if whitespace {
+				continue
+			}
+		}
+
+		if i >= len(value) {
+			return elems, true
+		}
+		i += len(sep)
+	}
+}
+
 type DecoderField struct {
 	Name            string // key in the input
 	Required        bool
@@ -93,6 +238,7 @@ type DecoderField struct {
 	StructDecoder *Decoder // If the field is a nested struct or a slice of nested structs, this is set to the decoder.
 
 	fieldIndex []int // Given the struct Value, how can we get the field with .FieldByIndex(fieldIndex)
+	keyIndex   []int // For slices of structs with a meta_key, the index of the key field in each element
 
 	// Basic type information:
 	fieldType      reflect.Type // Type of the field. Eg, TypeOf(field)
@@ -117,6 +263,12 @@ type Decoder struct {
 
 type DecoderOptions struct {
 	TimeFormats []string
+	// Location is the default TimeOptions.Location for Time fields without a meta_location tag.
+	Location *time.Location
+	// UTC sets TimeOptions.UTC on every Time field.
+	UTC bool
+	// Clock replaces time.Now when evaluating time expressions and relative limits of Time and Date fields.
+	Clock Clock
 }
 
 func NewDecoderWithOptions(destStruct interface{}, options DecoderOptions) *Decoder {
@@ -241,6 +393,9 @@ func NewDecoderWithOptions(destStruct interface{}, options DecoderOptions) *Deco
 					dfield.Options = getParsedOptions(valuer, fieldStruct, options)
 				} else if elemIndirectedKind == reflect.Struct {
 					dfield.fieldCategory = categorySliceOfStructs
+					if dfield.SliceOptions.constrained() && dfield.SliceOptions.Key == "" {
+						panic(fmt.Sprintf("meta: meta_unique and meta_sorted need a meta_key for %s", fieldStruct.Name))
+					}
 					if elemIndirectedType == destType {
 						dfield.StructDecoder = decoder
 					} else {
@@ -251,23 +406,70 @@ func NewDecoderWithOptions(destStruct interface{}, options DecoderOptions) *Deco
 				}
 			}
 
+			if dfield.DocPattern == "" {
+				dfield.DocPattern = optionsDocPattern(dfield.Options)
+			}
+
 			decoder.Fields = append(decoder.Fields, dfield)
 		}
 	}
 
+	// done once every field is known, as slices of structs can hold the struct being decoded
+	for i := range decoder.Fields {
+		decoder.Fields[i].resolveKey()
+	}
+
 	return decoder
 }
 
+// optionsDocPattern returns the pattern enforced by String and StringSlice options, used as
+// DocPattern when the field has no doc_pattern tag.
+func optionsDocPattern(options interface{}) string {
+	switch opts := options.(type) {
+	case *StringOptions:
+		return opts.docPattern()
+	case *StringSliceOptions:
+		return opts.StringOptions.docPattern()
+	}
+	return ""
+}
+
 func getParsedOptions(valuer Valuer, fieldStruct reflect.StructField, options DecoderOptions) interface{} {
 	parsedOptions := valuer.ParseOptions(fieldStruct.Tag)
-	if timeOptions, ok := parsedOptions.(*TimeOptions); ok && len(options.TimeFormats) > 0 {
-		timeOptions.Format = options.TimeFormats
-		parsedOptions = timeOptions
+	switch opts := parsedOptions.(type) {
+	case *TimeOptions:
+		applyTimeDecoderOptions(opts, fieldStruct.Tag, options)
+	case *TimeRangeOptions:
+		applyTimeDecoderOptions(opts.TimeOptions, fieldStruct.Tag, options)
+	case *TimeSliceOptions:
+		applyTimeDecoderOptions(opts.TimeOptions, fieldStruct.Tag, options)
+	case *DateOptions:
+		if options.Clock != nil {
+			opts.Clock = options.Clock
+		}
 	}
 
 	return parsedOptions
 }
 
+func applyTimeDecoderOptions(timeOptions *TimeOptions, tag reflect.StructTag, options DecoderOptions) {
+	if len(options.TimeFormats) > 0 {
+		timeOptions.Format = options.TimeFormats
+	}
+	if options.UTC {
+		timeOptions.UTC = true
+	}
+	if options.Clock != nil {
+		timeOptions.Clock = options.Clock
+	}
+	if options.Location != nil && timeOptions.Location == nil {
+		timeOptions.Location = options.Location
+		// absolute limits without a zone have to be parsed again in the new location
+		timeOptions.MinDate = newDateLimit(tag.Get("meta_min"), timeOptions)
+		timeOptions.MaxDate = newDateLimit(tag.Get("meta_max"), timeOptions)
+	}
+}
+
 func NewDecoder(destStruct interface{}) *Decoder {
 	return NewDecoderWithOptions(destStruct, DecoderOptions{})
 }
@@ -330,7 +532,7 @@ func (d *Decoder) decode(destValue reflect.Value, src source) ErrorHash {
 					fieldValue.Set(reflect.New(dfield.indirectedType))
 					valuerValue = fieldValue
 				}
-				err = valuerValue.Interface().(Valuer).JSONValue(nestedValues.Path(), val, dfield.Options)
+				err = valuerValue.Interface().(Valuer).JSONValue(nestedValues.Path(), val, resolveOptions(dfield.Options, src))
 				if err != nil && !dfield.DiscardInvalid {
 					errs = addError(errs, metaName, err)
 				}
@@ -392,6 +594,7 @@ func (d *Decoder) decode(destValue reflect.Value, src source) ErrorHash {
 
 			// initialize the slice to an empty slice rather than the zero value
 			sliceValue.Set(reflect.MakeSlice(dfield.fieldType, 0, 0))
+			elemOptions := resolveOptions(dfield.Options, src)
 
 			for i := 0; true; i += 1 {
 				nestedValues := sliceSrc.Get(fmt.Sprint(i)) // foo_bar.0, foo_bar.1, ...
@@ -410,7 +613,7 @@ func (d *Decoder) decode(destValue reflect.Value, src source) ErrorHash {
 				if dfield.fieldCategory == categorySliceOfValues {
 					var val interface{}
 					nestedValues.Value(&val)
-					err = elPtrValue.Interface().(Valuer).JSONValue(nestedValues.Path(), val, dfield.Options)
+					err = elPtrValue.Interface().(Valuer).JSONValue(nestedValues.Path(), val, elemOptions)
 				} else {
 					hashErr := dfield.StructDecoder.decode(elPtrValue, nestedValues)
 					if hashErr != nil {
@@ -430,6 +633,14 @@ func (d *Decoder) decode(destValue reflect.Value, src source) ErrorHash {
 				}
 			}
 
+			if errorsInSlice.Len() == 0 {
+				var err Errorable
+				if sliceValue, err = dfield.SliceOptions.constrain(sliceValue, dfield.elementKey); err != nil {
+					errs = addError(errs, metaName, err)
+					continue
+				}
+			}
+
 			length := sliceValue.Len()
 			if dfield.MinLengthPresent && dfield.MinLength > length {
 				errs = addError(errs, metaName, ErrMinLength)
@@ -481,6 +692,13 @@ func (d *Decoder) NewDecoded(values url.Values, r io.Reader) (interface{}, Error
 	return dest, nil
 }
 
+func resolveOptions(options interface{}, src source) interface{} {
+	if o, ok := options.(sourceOptions); ok {
+		return o.withSource(src)
+	}
+	return options
+}
+
 func addError(errs ErrorHash, key string, value Errorable) ErrorHash {
 	if errs == nil {
 		errs = make(ErrorHash)
@@ -0,0 +1,125 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+//
+// Postgres array text
+//
+
+// splitPostgresArray splits the text form of a one-dimensional Postgres array into its elements,
+// reporting which ones are NULL. An optional dimension decoration like "[1:3]=" is skipped.
+func splitPostgresArray(s string) (elems []string, nulls []bool, err error) {
+	if strings.HasPrefix(s, "[") {
+		if i := strings.Index(s, "="); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, nil, fmt.Errorf("meta: invalid postgres array %q", s)
+	}
+	body := s[1 : len(s)-1]
+
+	elems = []string{}
+	nulls = []bool{}
+	if strings.TrimSpace(body) == "" {
+		return elems, nulls, nil
+	}
+
+	for i := 0; ; {
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i >= len(body) {
+			return nil, nil, fmt.Errorf("meta: invalid postgres array %q", s)
+		}
+
+		var elem strings.Builder
+		quoted := body[i] == '"'
+		if quoted {
+			i++
+			for ; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' {
+					i++
+					if i == len(body) {
+						break
+					}
+				}
+				elem.WriteByte(body[i])
+			}
+			if i >= len(body) {
+				return nil, nil, fmt.Errorf("meta: unterminated quote in postgres array %q", s)
+			}
+			i++
+		} else {
+			for ; i < len(body) && body[i] != ','; i++ {
+				switch body[i] {
+				case '{', '}', '"':
+					return nil, nil, fmt.Errorf("meta: unsupported postgres array %q", s)
+				case '\\':
+					i++
+					if i == len(body) {
+						return nil, nil, fmt.Errorf("meta: invalid postgres array %q", s)
+					}
+				}
+				elem.WriteByte(body[i])
+			}
+		}
+
+		value := elem.String()
+		null := false
+		if !quoted {
+			value = strings.TrimRight(value, " ")
+			null = strings.EqualFold(value, "NULL")
+		}
+		elems = append(elems, value)
+		nulls = append(nulls, null)
+
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i == len(body) {
+			return elems, nulls, nil
+		}
+		if body[i] != ',' {
+			return nil, nil, fmt.Errorf("meta: invalid postgres array %q", s)
+		}
+		i++
+	}
+}
+
+// formatPostgresArray renders elems as the text form of a Postgres array, quoting the elements
+// that need it. Elements whose nulls entry is true are written as NULL; nulls may be nil.
+func formatPostgresArray(elems []string, nulls []bool) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, elem := range elems {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if nulls != nil && nulls[i] {
+			b.WriteString("NULL")
+			continue
+		}
+		if !postgresArrayNeedsQuotes(elem) {
+			b.WriteString(elem)
+			continue
+		}
+		b.WriteByte('"')
+		for _, r := range elem {
+			if r == '"' || r == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func postgresArrayNeedsQuotes(elem string) bool {
+	return elem == "" || strings.EqualFold(elem, "NULL") || strings.ContainsAny(elem, "{},\"\\ \t\n\r\v\f")
+}
@@ -0,0 +1,782 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Core Types and Structures
+//
+
+// RecurrenceFrequency is the FREQ of a recurrence rule.
+type RecurrenceFrequency string
+
+const (
+	FrequencySecondly RecurrenceFrequency = "SECONDLY"
+	FrequencyMinutely RecurrenceFrequency = "MINUTELY"
+	FrequencyHourly   RecurrenceFrequency = "HOURLY"
+	FrequencyDaily    RecurrenceFrequency = "DAILY"
+	FrequencyWeekly   RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly  RecurrenceFrequency = "MONTHLY"
+	FrequencyYearly   RecurrenceFrequency = "YEARLY"
+)
+
+// RecurrenceDay is a BYDAY entry, eg "MO", or "-1FR" for the last Friday of the month or year.
+// N is 0 for every such weekday.
+type RecurrenceDay struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule is the subset of an RFC 5545 recurrence rule made of FREQ, INTERVAL, BYDAY,
+// BYMONTHDAY, COUNT and UNTIL, together with its DTSTART. Occurrences keep the time of day and
+// location of Start.
+type RecurrenceRule struct {
+	Freq       RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+	Start      time.Time
+}
+
+type Recurrence struct {
+	Val RecurrenceRule
+	Nullity
+	Presence
+	Path string
+}
+
+type RecurrenceOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// Frequencies restricts FREQ to the given values.
+	// Configured via meta_frequency tag, case-insensitive.
+	// Default: nil (any frequency)
+	// Example: `meta_frequency:"daily,weekly,monthly"`
+	Frequencies []RecurrenceFrequency
+	// StartField names another input field holding the DTSTART of rules that don't include one.
+	// It is parsed like a Time with default options, eg "2024-01-01T09:00:00Z" or "tomorrow".
+	// Configured via meta_start_field tag.
+	// Example: `meta_start_field:"starts_at"`
+	StartField string
+	// start is the value of StartField for the current input
+	start time.Time
+}
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var recurrenceDayRegex = regexp.MustCompile(`^([+-]?\d{1,2})?(SU|MO|TU|WE|TH|FR|SA)$`)
+
+// recurrenceFixedUnits are the period lengths of frequencies that don't depend on the calendar.
+var recurrenceFixedUnits = map[RecurrenceFrequency]time.Duration{
+	FrequencySecondly: time.Second,
+	FrequencyMinutely: time.Minute,
+	FrequencyHourly:   time.Hour,
+}
+
+const (
+	iCalUTCLayout      = "20060102T150405Z"
+	iCalFloatingLayout = "20060102T150405"
+	iCalDateLayout     = "20060102"
+
+	// maxEmptyRecurrencePeriods stops expanding rules whose parts can no longer match, eg
+	// "FREQ=YEARLY;BYMONTHDAY=30;BYDAY=53MO". Sub-daily rules count empty days rather than periods.
+	maxEmptyRecurrencePeriods = 10000
+)
+
+//
+// Constructors
+//
+
+func NewRecurrence(rule RecurrenceRule) Recurrence {
+	return Recurrence{rule, Nullity{false}, Presence{true}, ""}
+}
+
+// ParseRecurrenceRule parses an RRULE value like "FREQ=WEEKLY;BYDAY=MO,WE", optionally prefixed with
+// "RRULE:" and preceded by a DTSTART line, eg "DTSTART;TZID=Europe/Paris:20240101T090000\nRRULE:FREQ=DAILY".
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	var rule RecurrenceRule
+	var ruleLine string
+
+	for _, line := range strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		line = strings.TrimSpace(line)
+		upper := strings.ToUpper(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(upper, "DTSTART"):
+			start, err := parseRecurrenceStart(line[len("DTSTART"):])
+			if err != nil {
+				return RecurrenceRule{}, err
+			}
+			rule.Start = start
+		case strings.HasPrefix(upper, "RRULE:"):
+			ruleLine = line[len("RRULE:"):]
+		default:
+			ruleLine = line
+		}
+	}
+
+	if ruleLine == "" {
+		return RecurrenceRule{}, fmt.Errorf("meta: missing RRULE")
+	}
+	if err := rule.parseParts(ruleLine); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return rule, nil
+}
+
+//
+// RecurrenceRule Methods
+//
+
+// String returns the rule in RFC 5545 form, with a DTSTART line when Start is set.
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(iCalUTCLayout))
+	}
+
+	rule := "RRULE:" + strings.Join(parts, ";")
+	if r.Start.IsZero() {
+		return rule
+	}
+	return formatRecurrenceStart(r.Start) + "\n" + rule
+}
+
+// Between returns the occurrences within [from, to], both included. Rules without a Start
+// start at from. The caller bounds the window; a SECONDLY rule over a year has millions of occurrences.
+func (r RecurrenceRule) Between(from, to time.Time) []time.Time {
+	var out []time.Time
+	r.expand(from, to, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return true
+	})
+	return out
+}
+
+// Next returns the first occurrence strictly after after, and false when there is none.
+// Rules without a Start start at after.
+func (r RecurrenceRule) Next(after time.Time) (time.Time, bool) {
+	var next time.Time
+	var found bool
+	r.expand(after, time.Time{}, func(t time.Time) bool {
+		if t.After(after) {
+			next, found = t, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+func (d RecurrenceDay) String() string {
+	name := strings.ToUpper(dayNames[d.Weekday][:2])
+	if d.N == 0 {
+		return name
+	}
+	return strconv.Itoa(d.N) + name
+}
+
+//
+// Core Recurrence Methods
+//
+
+func (r *Recurrence) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &RecurrenceOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		StartField:   tag.Get("meta_start_field"),
+	}
+
+	for _, freq := range parseLowerList(tag.Get("meta_frequency")) {
+		f := RecurrenceFrequency(strings.ToUpper(freq))
+		if _, ok := recurrencePeriod[f]; !ok {
+			panic("unknown meta_frequency " + freq)
+		}
+		opts.Frequencies = append(opts.Frequencies, f)
+	}
+
+	return opts
+}
+
+func (r *Recurrence) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	r.Path = path
+	if i == nil {
+		return r.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return r.FormValue(value, options)
+	case RecurrenceRule:
+		return r.FormValue(value.String(), options)
+	}
+	return ErrRecurrence
+}
+
+func (r *Recurrence) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*RecurrenceOptions)
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if opts.Null {
+			r.Present = true
+			r.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			r.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	rule, err := ParseRecurrenceRule(value)
+	if err != nil {
+		return ErrRecurrence
+	}
+
+	if len(opts.Frequencies) > 0 {
+		allowed := false
+		for _, f := range opts.Frequencies {
+			allowed = allowed || f == rule.Freq
+		}
+		if !allowed {
+			return ErrRecurrenceFrequency
+		}
+	}
+
+	if rule.Start.IsZero() {
+		rule.Start = opts.start
+	}
+
+	r.Val = rule
+	r.Present = true
+	return nil
+}
+
+func (r Recurrence) Value() (driver.Value, error) {
+	if r.Present && !r.Null {
+		return r.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	if r.Present && !r.Null {
+		return MetaJson.Marshal(r.Val.String())
+	}
+	return nullString, nil
+}
+
+func (r *Recurrence) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		r.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	rule, err := ParseRecurrenceRule(s)
+	if err != nil {
+		return err
+	}
+
+	r.Val = rule
+	r.Presence = Presence{true}
+	r.Nullity = Nullity{false}
+	return nil
+}
+
+// withSource returns a copy of opts holding the DTSTART found in the StartField input, if any.
+func (opts *RecurrenceOptions) withSource(src source) interface{} {
+	if opts.StartField == "" {
+		return opts
+	}
+
+	var val interface{}
+	src.Get(opts.StartField).Value(&val)
+	if val == nil {
+		return opts
+	}
+
+	var start Time
+	var tempT Time
+	if err := start.JSONValue("", val, tempT.ParseOptions("")); err != nil || !start.Present {
+		return opts
+	}
+
+	resolved := *opts
+	resolved.start = start.Val
+	return &resolved
+}
+
+//
+// Rule Parsing
+//
+
+func (r *RecurrenceRule) parseParts(value string) error {
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		name = strings.ToUpper(name)
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" {
+			return fmt.Errorf("meta: invalid RRULE part %q", part)
+		}
+		if seen[name] {
+			return fmt.Errorf("meta: duplicate RRULE part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = RecurrenceFrequency(val)
+			if _, ok := recurrencePeriod[r.Freq]; !ok {
+				err = fmt.Errorf("meta: unknown FREQ %s", val)
+			}
+		case "INTERVAL":
+			r.Interval, err = parsePositiveInt(val)
+		case "COUNT":
+			r.Count, err = parsePositiveInt(val)
+		case "UNTIL":
+			r.Until, err = parseICalTime(val, r.location())
+		case "BYDAY":
+			r.ByDay, err = parseRecurrenceDays(val)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseMonthDays(val)
+		default:
+			err = fmt.Errorf("meta: unsupported RRULE part %s", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.validate()
+}
+
+func (r *RecurrenceRule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("meta: missing FREQ")
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("meta: COUNT and UNTIL are mutually exclusive")
+	}
+	if r.Freq == FrequencyWeekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("meta: BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, day := range r.ByDay {
+		switch {
+		case day.N == 0:
+		case r.Freq == FrequencyMonthly && day.N >= -5 && day.N <= 5:
+		case r.Freq == FrequencyYearly:
+		default:
+			return fmt.Errorf("meta: BYDAY %s is not allowed with FREQ=%s", day, r.Freq)
+		}
+	}
+	return nil
+}
+
+func (r *RecurrenceRule) location() *time.Location {
+	if r.Start.IsZero() {
+		return time.UTC
+	}
+	return r.Start.Location()
+}
+
+func parsePositiveInt(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("meta: expected a positive integer, got %q", value)
+	}
+	return n, nil
+}
+
+func parseRecurrenceDays(value string) ([]RecurrenceDay, error) {
+	var days []RecurrenceDay
+	for _, s := range strings.Split(value, ",") {
+		m := recurrenceDayRegex.FindStringSubmatch(strings.TrimSpace(s))
+		if m == nil {
+			return nil, fmt.Errorf("meta: invalid BYDAY %q", s)
+		}
+		day := RecurrenceDay{Weekday: recurrenceWeekdays[m[2]]}
+		if m[1] != "" {
+			day.N, _ = strconv.Atoi(m[1])
+			if day.N == 0 || day.N < -53 || day.N > 53 {
+				return nil, fmt.Errorf("meta: invalid BYDAY %q", s)
+			}
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseMonthDays(value string) ([]int, error) {
+	var days []int
+	for _, s := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("meta: invalid BYMONTHDAY %q", s)
+		}
+		days = append(days, n)
+	}
+	return days, nil
+}
+
+// parseRecurrenceStart parses what follows DTSTART: optional ";TZID=<zone>" or ";VALUE=DATE" parameters,
+// then ":" and the time.
+func parseRecurrenceStart(value string) (time.Time, error) {
+	params, val, ok := strings.Cut(value, ":")
+	if !ok {
+		return time.Time{}, fmt.Errorf("meta: invalid DTSTART")
+	}
+
+	loc := time.UTC
+	for _, param := range strings.Split(params, ";") {
+		name, pval, _ := strings.Cut(param, "=")
+		if strings.EqualFold(name, "TZID") {
+			l, ok := lookupLocation(pval)
+			if !ok {
+				return time.Time{}, fmt.Errorf("meta: unknown DTSTART TZID %q", pval)
+			}
+			loc = l
+		}
+	}
+	return parseICalTime(strings.TrimSpace(val), loc)
+}
+
+// parseICalTime parses RFC 5545 DATE-TIME values in UTC ("20240101T090000Z") or floating in loc
+// ("20240101T090000"), and DATE values as midnight in loc.
+func parseICalTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(iCalUTCLayout, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(iCalFloatingLayout, value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(iCalDateLayout, value, loc); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("meta: invalid date-time %q", value)
+}
+
+func formatRecurrenceStart(start time.Time) string {
+	loc := start.Location()
+	if loc == time.UTC {
+		return "DTSTART:" + start.Format(iCalUTCLayout)
+	}
+	if _, err := time.LoadLocation(loc.String()); err != nil || loc.String() == "Local" {
+		return "DTSTART:" + start.UTC().Format(iCalUTCLayout)
+	}
+	return "DTSTART;TZID=" + loc.String() + ":" + start.Format(iCalFloatingLayout)
+}
+
+//
+// Expansion
+//
+
+// recurrencePeriod returns the start of the k-th period after the one containing start, before the
+// interval is applied. Daily and longer periods start at midnight, weeks on Monday.
+var recurrencePeriod = map[RecurrenceFrequency]func(start time.Time, k int) time.Time{
+	FrequencySecondly: func(start time.Time, k int) time.Time { return start.Add(time.Duration(k) * time.Second) },
+	FrequencyMinutely: func(start time.Time, k int) time.Time { return start.Add(time.Duration(k) * time.Minute) },
+	FrequencyHourly:   func(start time.Time, k int) time.Time { return start.Add(time.Duration(k) * time.Hour) },
+	FrequencyDaily: func(start time.Time, k int) time.Time {
+		return time.Date(start.Year(), start.Month(), start.Day()+k, 0, 0, 0, 0, start.Location())
+	},
+	FrequencyWeekly: func(start time.Time, k int) time.Time {
+		monday := start.Day() - (int(start.Weekday())+6)%7
+		return time.Date(start.Year(), start.Month(), monday+7*k, 0, 0, 0, 0, start.Location())
+	},
+	FrequencyMonthly: func(start time.Time, k int) time.Time {
+		return time.Date(start.Year(), start.Month()+time.Month(k), 1, 0, 0, 0, 0, start.Location())
+	},
+	FrequencyYearly: func(start time.Time, k int) time.Time {
+		return time.Date(start.Year()+k, time.January, 1, 0, 0, 0, 0, start.Location())
+	},
+}
+
+// expand calls fn with each occurrence in order until fn returns false, the rule ends or, unless
+// it is zero, a period starts after end. from is only used to skip periods that can't reach it,
+// and as the start of rules without one.
+func (r RecurrenceRule) expand(from, end time.Time, fn func(time.Time) bool) {
+	start := r.Start
+	if start.IsZero() {
+		start = from
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	k := 0
+	if r.Count == 0 && from.After(start) {
+		k = r.periodsBefore(start, from) / interval * interval
+	}
+
+	count := 0
+	for empty := 0; empty < maxEmptyRecurrencePeriods; k += interval {
+		period := recurrencePeriod[r.Freq](start, k)
+		if (!r.Until.IsZero() && period.After(r.Until)) || (!end.IsZero() && period.After(end)) {
+			return
+		}
+
+		candidates := r.candidates(start, period)
+		if len(candidates) == 0 {
+			empty++
+			if unit, ok := recurrenceFixedUnits[r.Freq]; ok {
+				// the day doesn't match, so neither does any other period of it
+				k += r.periodsToNextDay(period, unit*time.Duration(interval)) - interval
+			}
+			continue
+		}
+		empty = 0
+
+		for _, t := range candidates {
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			count++
+			if !fn(t) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// periodsToNextDay returns the number of periods, a multiple of the interval, from period to the
+// first one starting on the next day, for SECONDLY, MINUTELY and HOURLY rules.
+func (r RecurrenceRule) periodsToNextDay(period time.Time, step time.Duration) int {
+	next := time.Date(period.Year(), period.Month(), period.Day()+1, 0, 0, 0, 0, period.Location())
+	steps := (next.Sub(period) + step - 1) / step
+	return int(steps) * int(step/recurrenceFixedUnits[r.Freq])
+}
+
+// periodsBefore returns a number of periods between start and from that is never too large, so
+// expansion can skip ahead without missing occurrences.
+func (r RecurrenceRule) periodsBefore(start, from time.Time) int {
+	from = from.In(start.Location())
+	var k int
+	switch r.Freq {
+	case FrequencyYearly:
+		k = from.Year() - start.Year()
+	case FrequencyMonthly:
+		k = (from.Year()-start.Year())*12 + int(from.Month()-start.Month())
+	case FrequencyWeekly:
+		k = int(from.Sub(start) / (7 * 24 * time.Hour))
+	case FrequencyDaily:
+		k = int(from.Sub(start) / (24 * time.Hour))
+	default:
+		k = int(from.Sub(start) / recurrenceFixedUnits[r.Freq])
+	}
+	if k -= 1; k < 0 {
+		return 0
+	}
+	return k
+}
+
+// candidates returns the sorted occurrences of the period starting at period, before COUNT and UNTIL.
+func (r RecurrenceRule) candidates(start, period time.Time) []time.Time {
+	if _, ok := recurrenceFixedUnits[r.Freq]; ok {
+		if r.matchesDay(period) {
+			return []time.Time{period}
+		}
+		return nil
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		days = []time.Time{period}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			days = []time.Time{period.AddDate(0, 0, (int(start.Weekday())+6)%7)}
+		}
+		for _, day := range r.ByDay {
+			days = append(days, period.AddDate(0, 0, (int(day.Weekday)+6)%7))
+		}
+	case FrequencyMonthly:
+		days = r.expandMonth(start, period)
+	case FrequencyYearly:
+		if len(r.ByMonthDay) > 0 {
+			for month := 0; month < 12; month++ {
+				days = append(days, r.expandMonth(start, period.AddDate(0, month, 0))...)
+			}
+		} else if len(r.ByDay) > 0 {
+			days = expandWeekdays(r.ByDay, period, period.AddDate(1, 0, 0))
+		} else if d := time.Date(period.Year(), start.Month(), start.Day(), 0, 0, 0, 0, period.Location()); d.Month() == start.Month() {
+			days = []time.Time{d}
+		}
+	}
+
+	var out []time.Time
+	for _, day := range days {
+		t := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		if r.matchesDay(t) {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return dedupeTimes(out)
+}
+
+// expandMonth returns the days of the month starting at month selected by BYMONTHDAY, BYDAY, or
+// the day of start.
+func (r RecurrenceRule) expandMonth(start, month time.Time) []time.Time {
+	next := month.AddDate(0, 1, 0)
+	length := next.AddDate(0, 0, -1).Day()
+
+	if len(r.ByMonthDay) > 0 {
+		var days []time.Time
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n = length + n + 1
+			}
+			if n >= 1 && n <= length {
+				days = append(days, month.AddDate(0, 0, n-1))
+			}
+		}
+		return days
+	}
+	if len(r.ByDay) > 0 {
+		return expandWeekdays(r.ByDay, month, next)
+	}
+	if start.Day() <= length {
+		return []time.Time{month.AddDate(0, 0, start.Day()-1)}
+	}
+	return nil
+}
+
+// expandWeekdays returns the days in [first, end) matching days, counting ordinals within that span.
+func expandWeekdays(days []RecurrenceDay, first, end time.Time) []time.Time {
+	var out []time.Time
+	for _, day := range days {
+		var matching []time.Time
+		for d := first.AddDate(0, 0, (int(day.Weekday)-int(first.Weekday())+7)%7); d.Before(end); d = d.AddDate(0, 0, 7) {
+			matching = append(matching, d)
+		}
+		switch {
+		case day.N == 0:
+			out = append(out, matching...)
+		case day.N > 0 && day.N <= len(matching):
+			out = append(out, matching[day.N-1])
+		case day.N < 0 && -day.N <= len(matching):
+			out = append(out, matching[len(matching)+day.N])
+		}
+	}
+	return out
+}
+
+// matchesDay applies BYDAY and BYMONTHDAY when they limit rather than expand the frequency.
+func (r RecurrenceRule) matchesDay(t time.Time) bool {
+	limitsDay := r.Freq != FrequencyWeekly && !(len(r.ByMonthDay) == 0 && (r.Freq == FrequencyMonthly || r.Freq == FrequencyYearly))
+	if limitsDay && len(r.ByDay) > 0 {
+		matched := false
+		for _, day := range r.ByDay {
+			matched = matched || (day.Weekday == t.Weekday() && r.matchesOrdinal(day.N, t))
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if r.Freq != FrequencyMonthly && r.Freq != FrequencyYearly && len(r.ByMonthDay) > 0 {
+		length := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		matched := false
+		for _, n := range r.ByMonthDay {
+			matched = matched || n == t.Day() || length+n+1 == t.Day()
+		}
+		return matched
+	}
+	return true
+}
+
+// matchesOrdinal reports whether t is the n-th of its weekday within its month for MONTHLY rules,
+// or within its year for YEARLY rules, counting from the end when n is negative. 0 matches any.
+func (r RecurrenceRule) matchesOrdinal(n int, t time.Time) bool {
+	if n == 0 {
+		return true
+	}
+
+	day, length := t.Day(), time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if r.Freq == FrequencyYearly {
+		day, length = t.YearDay(), time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, t.Location()).YearDay()
+	}
+	if n > 0 {
+		return (day-1)/7+1 == n
+	}
+	return -((length-day)/7 + 1) == n
+}
+
+func dedupeTimes(times []time.Time) []time.Time {
+	out := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
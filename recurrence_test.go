@@ -0,0 +1,225 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withRecurrence struct {
+	A Recurrence `meta_required:"true"`
+}
+
+var withRecurrenceDecoder = NewDecoder(&withRecurrence{})
+
+func TestRecurrenceSuccess(t *testing.T) {
+	for input, expected := range map[string]string{
+		"FREQ=DAILY": "RRULE:FREQ=DAILY",
+		"rrule:freq=weekly;byday=mo,we;interval=2":   "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=6":            "RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=6",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15,-1":            "RRULE:FREQ=MONTHLY;BYMONTHDAY=1,15,-1",
+		"FREQ=YEARLY;BYDAY=+20MO;UNTIL=20301231":     "RRULE:FREQ=YEARLY;BYDAY=20MO;UNTIL=20301231T000000Z",
+		"FREQ=HOURLY;INTERVAL=1":                     "RRULE:FREQ=HOURLY",
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY": "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY",
+		"DTSTART;TZID=Europe/Paris:20240101T090000\r\nRRULE:FREQ=WEEKLY;UNTIL=20240301T000000Z": "DTSTART;TZID=Europe/Paris:20240101T090000\nRRULE:FREQ=WEEKLY;UNTIL=20240301T000000Z",
+	} {
+		var inputs withRecurrence
+		e := withRecurrenceDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Present, true, input)
+		assertEqual(t, inputs.A.Val.String(), expected, input)
+	}
+}
+
+func TestRecurrenceInvalid(t *testing.T) {
+	var inputs withRecurrence
+
+	for _, bad := range []string{
+		"DAILY",
+		"FREQ=FORTNIGHTLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=3;UNTIL=20240101",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"DTSTART;TZID=Mars/Olympus:20240101T090000\nRRULE:FREQ=DAILY",
+		"DTSTART:20240101T090000Z",
+	} {
+		e := withRecurrenceDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrRecurrence}, bad)
+	}
+
+	e := withRecurrenceDecoder.DecodeJSON(&inputs, []byte(`{"a":5}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRecurrence})
+
+	e = withRecurrenceDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withRecurrenceDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestRecurrenceFrequency(t *testing.T) {
+	var inputs struct {
+		A Recurrence `meta_frequency:"daily,weekly"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"FREQ=WEEKLY"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"FREQ=HOURLY"}})
+	assertEqual(t, e, ErrorHash{"a": ErrRecurrenceFrequency})
+}
+
+func TestRecurrenceStartField(t *testing.T) {
+	var inputs struct {
+		StartsAt Time
+		Schedule Recurrence `meta_start_field:"starts_at"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"starts_at":"2024-01-01T09:00:00Z","schedule":"FREQ=DAILY;COUNT=2"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.Schedule.Val.Start.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)))
+
+	e = d.DecodeJSON(&inputs, []byte(`{"starts_at":"2024-01-01T09:00:00Z","schedule":"DTSTART:20230601T120000Z\nRRULE:FREQ=DAILY"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.Schedule.Val.Start.Equal(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+	inputs.Schedule = Recurrence{}
+	e = d.DecodeJSON(&inputs, []byte(`{"schedule":"FREQ=DAILY"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.Schedule.Val.Start.IsZero())
+}
+
+func mustParseRecurrence(t *testing.T, value string) RecurrenceRule {
+	rule, err := ParseRecurrenceRule(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+func dates(values ...string) []time.Time {
+	out := make([]time.Time, len(values))
+	for i, v := range values {
+		out[i], _ = time.Parse(time.RFC3339, v)
+	}
+	return out
+}
+
+func assertTimes(t *testing.T, actual, expected []time.Time, msg string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("%s: expected %v, got %v", msg, expected, actual)
+	}
+	for i := range expected {
+		if !actual[i].Equal(expected[i]) {
+			t.Fatalf("%s: expected %v, got %v", msg, expected, actual)
+		}
+	}
+}
+
+func TestRecurrenceBetween(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	for rule, expected := range map[string][]time.Time{
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=3": dates("2024-01-01T09:00:00Z", "2024-01-02T09:00:00Z", "2024-01-03T09:00:00Z"),
+		"DTSTART:20240103T100000Z\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20240131T000000Z": dates(
+			"2024-01-03T10:00:00Z", "2024-01-15T10:00:00Z", "2024-01-17T10:00:00Z", "2024-01-29T10:00:00Z"),
+		"DTSTART:20240101T080000Z\nRRULE:FREQ=MONTHLY;BYDAY=-1FR": dates("2024-01-26T08:00:00Z", "2024-02-23T08:00:00Z", "2024-03-29T08:00:00Z"),
+		"DTSTART:20240101T080000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=-1,15;COUNT=4": dates(
+			"2024-01-15T08:00:00Z", "2024-01-31T08:00:00Z", "2024-02-15T08:00:00Z", "2024-02-29T08:00:00Z"),
+		"DTSTART:20240131T080000Z\nRRULE:FREQ=MONTHLY":                                               dates("2024-01-31T08:00:00Z", "2024-03-31T08:00:00Z"),
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=MONTHLY;BYDAY=MO;BYMONTHDAY=1":                         dates("2024-01-01T00:00:00Z", "2024-04-01T00:00:00Z"),
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=MONTHLY;BYDAY=-1FR;BYMONTHDAY=-7,-6,-5,-4,-3,-2,-1,23": dates("2024-01-26T00:00:00Z", "2024-02-23T00:00:00Z", "2024-03-29T00:00:00Z"),
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=DAILY;BYDAY=SA;BYMONTHDAY=1,2":                         dates("2024-03-02T00:00:00Z"),
+		"DTSTART:20200229T120000Z\nRRULE:FREQ=YEARLY;COUNT=2":                                        dates("2024-02-29T12:00:00Z"),
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=YEARLY;BYDAY=10MO;COUNT=2":                             dates("2024-03-04T00:00:00Z", "2025-03-10T00:00:00Z"),
+	} {
+		r := mustParseRecurrence(t, rule)
+		to := apr
+		if r.Freq == FrequencyYearly {
+			to = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+		assertTimes(t, r.Between(jan, to), expected, rule)
+	}
+}
+
+func TestRecurrenceBetweenSkipsAhead(t *testing.T) {
+	r := mustParseRecurrence(t, "DTSTART:20000101T000000Z\nRRULE:FREQ=MINUTELY;INTERVAL=15")
+	from := time.Date(2024, 6, 1, 12, 5, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC)
+	assertTimes(t, r.Between(from, to), dates("2024-06-01T12:15:00Z", "2024-06-01T12:30:00Z", "2024-06-01T12:45:00Z", "2024-06-01T13:00:00Z"), "minutely")
+
+	r = mustParseRecurrence(t, "FREQ=WEEKLY;BYDAY=FR")
+	occurrences := r.Between(time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC))
+	assertTimes(t, occurrences, dates("2024-06-07T09:00:00Z", "2024-06-14T09:00:00Z"), "without start")
+}
+
+func TestRecurrenceLocation(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	r := mustParseRecurrence(t, "DTSTART;TZID=Europe/Paris:20240330T090000\nRRULE:FREQ=DAILY;COUNT=2")
+	occurrences := r.Between(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertTimes(t, occurrences, []time.Time{
+		time.Date(2024, 3, 30, 9, 0, 0, 0, paris),
+		time.Date(2024, 3, 31, 9, 0, 0, 0, paris),
+	}, "across DST")
+	assertEqual(t, occurrences[1].Sub(occurrences[0]), 23*time.Hour)
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	r := mustParseRecurrence(t, "DTSTART:20240101T090000Z\nRRULE:FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3")
+
+	next, ok := r.Next(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC))
+	assertEqual(t, ok, true)
+	assert(t, next.Equal(time.Date(2024, 1, 4, 9, 0, 0, 0, time.UTC)))
+
+	_, ok = r.Next(time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC))
+	assertEqual(t, ok, false)
+
+	sparse := mustParseRecurrence(t, "DTSTART:20240401T000000Z\nRRULE:FREQ=MINUTELY;BYMONTHDAY=31")
+	next, ok = sparse.Next(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	assertEqual(t, ok, true)
+	assert(t, next.Equal(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)), next)
+
+	sparse = mustParseRecurrence(t, "DTSTART:20240101T003000Z\nRRULE:FREQ=HOURLY;INTERVAL=5;BYDAY=TH;BYMONTHDAY=29")
+	next, ok = sparse.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertEqual(t, ok, true)
+	assert(t, next.Equal(time.Date(2024, 2, 29, 4, 30, 0, 0, time.UTC)), next)
+
+	never := mustParseRecurrence(t, "DTSTART:20240101T000000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1;BYDAY=2MO")
+	_, ok = never.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertEqual(t, ok, false)
+}
+
+func TestRecurrenceJSON(t *testing.T) {
+	r := NewRecurrence(mustParseRecurrence(t, "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=3"))
+
+	bs, err := json.Marshal(r)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=3"`)
+
+	var decoded Recurrence
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Val.String(), r.Val.String())
+
+	err = json.Unmarshal([]byte(`"FREQ=NEVER"`), &decoded)
+	assert(t, err != nil)
+
+	v, err := r.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=3")
+}
@@ -0,0 +1,403 @@
+package meta
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//
+// HTML sanitization for String
+//
+
+const (
+	SanitizeStripHTML  = "strip_html"
+	SanitizeEscapeHTML = "escape_html"
+	SanitizeAllowlist  = "allowlist"
+)
+
+// SanitizePolicy lists the elements, and their attributes, kept by meta_sanitize:"allowlist:<name>".
+// Everything else is removed, keeping the text of removed elements except for scripts and styles.
+type SanitizePolicy struct {
+	// Elements maps lower-case element names to their allowed attributes.
+	Elements map[string][]string
+	// URLSchemes are the schemes allowed in href and src attributes, eg "https". Relative URLs
+	// are always allowed.
+	URLSchemes []string
+}
+
+var (
+	sanitizePoliciesMu sync.RWMutex
+	sanitizePolicies   = map[string]*SanitizePolicy{
+		// basic allows inline and block formatting without links or images.
+		"basic": {
+			Elements: map[string][]string{
+				"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil,
+				"p": nil, "br": nil, "ul": nil, "ol": nil, "li": nil,
+				"code": nil, "pre": nil, "blockquote": nil,
+			},
+		},
+		// links is basic plus links to web and mail addresses.
+		"links": {
+			Elements: map[string][]string{
+				"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil,
+				"p": nil, "br": nil, "ul": nil, "ol": nil, "li": nil,
+				"code": nil, "pre": nil, "blockquote": nil,
+				"a": {"href", "title"},
+			},
+			URLSchemes: []string{"http", "https", "mailto"},
+		},
+	}
+)
+
+// RegisterSanitizePolicy makes policy available to meta_sanitize:"allowlist:<name>" tags. It is meant
+// to be called at init time, before decoders using the policy are created.
+func RegisterSanitizePolicy(name string, policy *SanitizePolicy) {
+	sanitizePoliciesMu.Lock()
+	defer sanitizePoliciesMu.Unlock()
+	sanitizePolicies[name] = policy
+}
+
+func lookupSanitizePolicy(name string) (*SanitizePolicy, bool) {
+	sanitizePoliciesMu.RLock()
+	defer sanitizePoliciesMu.RUnlock()
+	policy, ok := sanitizePolicies[name]
+	return policy, ok
+}
+
+// parseSanitizeOptions reads meta_sanitize and meta_sanitize_mode into opts.
+func parseSanitizeOptions(opts *StringOptions, tag reflect.StructTag) {
+	sanitize := tag.Get("meta_sanitize")
+	if sanitize == "" {
+		return
+	}
+
+	mode, name, _ := strings.Cut(sanitize, ":")
+	switch mode {
+	case SanitizeStripHTML, SanitizeEscapeHTML:
+	case SanitizeAllowlist:
+		policy, ok := lookupSanitizePolicy(name)
+		if !ok {
+			panic(fmt.Sprintf("meta: unknown sanitize policy %q", name))
+		}
+		opts.SanitizePolicy = policy
+	default:
+		panic("invalid meta_sanitize " + sanitize)
+	}
+	opts.Sanitize = mode
+
+	switch m := tag.Get("meta_sanitize_mode"); m {
+	case "", "clean":
+	case "error":
+		opts.SanitizeStrict = true
+	default:
+		panic("invalid meta_sanitize_mode " + m)
+	}
+}
+
+// sanitize applies opts.Sanitize to value. It reports false when markup was removed or escaped,
+// which is an error with SanitizeStrict.
+func (opts *StringOptions) sanitize(value string) (string, bool) {
+	switch opts.Sanitize {
+	case SanitizeStripHTML:
+		return stripHTML(value)
+	case SanitizeEscapeHTML:
+		_, clean := stripHTML(value)
+		return html.EscapeString(value), clean
+	case SanitizeAllowlist:
+		return opts.SanitizePolicy.sanitize(value)
+	}
+	return value, true
+}
+
+// stripHTML removes every tag and comment from value, and the content of scripts and styles,
+// returning plain text with entities decoded. Escaped markup, eg "&lt;script&gt;", is stripped as
+// well once decoded, so the result never holds a tag.
+func stripHTML(value string) (string, bool) {
+	clean := true
+	for {
+		text, stripped := stripTags(value)
+		clean = clean && !stripped
+
+		// each pass removes a "<" and decoding can't add more "<" and "&" than it removes, so this ends
+		value = html.UnescapeString(text)
+		if _, markup := stripTags(value); !markup {
+			return value, clean
+		}
+		clean = false
+	}
+}
+
+// stripTags returns the text of value without its tags, comments, scripts and styles, reporting
+// whether there were any. Entities are kept as is.
+func stripTags(value string) (string, bool) {
+	var b strings.Builder
+	stripped := false
+	for _, tok := range tokenizeHTML(value) {
+		if tok.kind == htmlText {
+			b.WriteString(tok.data)
+		} else {
+			stripped = true
+		}
+	}
+	return b.String(), stripped
+}
+
+// sanitize keeps the elements and attributes allowed by p, closing the elements left open. Text and
+// attribute values are re-escaped, so entities are normalized rather than escaped twice.
+func (p *SanitizePolicy) sanitize(value string) (string, bool) {
+	var b strings.Builder
+	var open []string
+	clean := true
+
+	for _, tok := range tokenizeHTML(value) {
+		switch tok.kind {
+		case htmlText:
+			b.WriteString(html.EscapeString(html.UnescapeString(tok.data)))
+		case htmlStartTag:
+			allowed, ok := p.Elements[tok.data]
+			if !ok {
+				clean = false
+				continue
+			}
+			b.WriteString("<" + tok.data)
+			for _, attr := range tok.attrs {
+				if !containsString(allowed, attr.name) || !p.allowedURL(attr) {
+					clean = false
+					continue
+				}
+				b.WriteString(" " + attr.name + `="` + html.EscapeString(html.UnescapeString(attr.value)) + `"`)
+			}
+			b.WriteString(">")
+			if !htmlVoidElements[tok.data] {
+				open = append(open, tok.data)
+			}
+		case htmlEndTag:
+			i := len(open) - 1
+			for i >= 0 && open[i] != tok.data {
+				i--
+			}
+			if i < 0 {
+				if _, ok := p.Elements[tok.data]; !ok || !htmlVoidElements[tok.data] {
+					clean = false
+				}
+				continue
+			}
+			for j := len(open) - 1; j >= i; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			open = open[:i]
+		default:
+			clean = false
+		}
+	}
+
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("</" + open[j] + ">")
+	}
+	return b.String(), clean
+}
+
+// allowedURL reports whether attr is not a URL attribute or has an allowed scheme.
+func (p *SanitizePolicy) allowedURL(attr htmlAttr) bool {
+	if attr.name != "href" && attr.name != "src" {
+		return true
+	}
+
+	// browsers ignore whitespace and control characters in schemes, eg "java\tscript:"
+	u := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(attr.value))
+
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.ContainsAny(u[:colon], "/?#") {
+		return true
+	}
+	return containsString(p.URLSchemes, strings.ToLower(u[:colon]))
+}
+
+//
+// Tokenizer
+//
+
+type htmlTokenKind int
+
+const (
+	htmlText htmlTokenKind = iota
+	htmlStartTag
+	htmlEndTag
+	// htmlComment also covers doctypes, processing instructions and the content of raw text
+	// elements like script
+	htmlComment
+)
+
+type htmlAttr struct {
+	name, value string
+}
+
+type htmlToken struct {
+	kind htmlTokenKind
+	// data is the text, or the lower-case tag name
+	data  string
+	attrs []htmlAttr
+}
+
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlRawTextElements have content that isn't markup, and is dropped with the element.
+var htmlRawTextElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "noscript": true, "noembed": true,
+	"noframes": true, "xmp": true, "textarea": true, "title": true, "plaintext": true,
+}
+
+// tokenizeHTML splits s into text, tags and comments. It follows the HTML tokenizer closely enough to
+// find every tag a browser would, and treats a "<" that can't start a tag as text.
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	text := 0
+	flush := func(end int) {
+		if end > text {
+			tokens = append(tokens, htmlToken{kind: htmlText, data: s[text:end]})
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' || i+1 == len(s) {
+			i++
+			continue
+		}
+
+		next := s[i+1]
+		switch {
+		case isASCIILetter(next):
+			flush(i)
+			tok, end := readHTMLTag(s, i+1, htmlStartTag)
+			tokens = append(tokens, tok)
+			i = end
+			if htmlRawTextElements[tok.data] {
+				closing := indexFold(s[i:], "</"+tok.data)
+				if closing < 0 {
+					closing = len(s) - i
+				}
+				if closing > 0 {
+					tokens = append(tokens, htmlToken{kind: htmlComment, data: s[i : i+closing]})
+				}
+				i += closing
+			}
+		case next == '/' && i+2 < len(s) && isASCIILetter(s[i+2]):
+			flush(i)
+			tok, end := readHTMLTag(s, i+2, htmlEndTag)
+			tokens = append(tokens, tok)
+			i = end
+		case strings.HasPrefix(s[i:], "<!--"):
+			flush(i)
+			end := strings.Index(s[i+4:], "-->")
+			if end < 0 {
+				i = len(s)
+			} else {
+				i += 4 + end + 3
+			}
+			tokens = append(tokens, htmlToken{kind: htmlComment})
+		case next == '!' || next == '?' || next == '/':
+			flush(i)
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				i = len(s)
+			} else {
+				i += end + 1
+			}
+			tokens = append(tokens, htmlToken{kind: htmlComment})
+		default:
+			i++
+			continue
+		}
+		text = i
+	}
+	flush(len(s))
+	return tokens
+}
+
+// readHTMLTag reads the tag whose name starts at i, returning it and the index after its ">".
+// A tag left open at the end of s takes the rest of s.
+func readHTMLTag(s string, i int, kind htmlTokenKind) (htmlToken, int) {
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	tok := htmlToken{kind: kind, data: strings.ToLower(s[start:i])}
+
+	for i < len(s) {
+		for i < len(s) && (isHTMLSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return tok, i + 1
+		}
+
+		start = i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && (s[i] != '=' || i == start) {
+			i++
+		}
+		attr := htmlAttr{name: strings.ToLower(s[start:i])}
+
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					attr.value = s[i+1:]
+					i = len(s)
+				} else {
+					attr.value = s[i+1 : i+1+end]
+					i += end + 2
+				}
+			} else {
+				start = i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				attr.value = s[start:i]
+			}
+		}
+		if kind == htmlStartTag {
+			tok.attrs = append(tok.attrs, attr)
+		}
+	}
+	return tok, len(s)
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// indexFold is strings.Index for an ASCII substr, ignoring case.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,126 @@
+package meta
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestStringSanitize(t *testing.T) {
+	var inputs struct {
+		Strip  String `meta_sanitize:"strip_html"`
+		Escape String `meta_sanitize:"escape_html"`
+		Basic  String `meta_sanitize:"allowlist:basic"`
+		Links  String `meta_sanitize:"allowlist:links"`
+	}
+	d := NewDecoder(&inputs)
+
+	cases := []struct {
+		field, in, out string
+	}{
+		{"strip", "plain text", "plain text"},
+		{"strip", "<b>bold</b> &amp; <i>italic</i>", "bold & italic"},
+		{"strip", "a <script>alert('x')</script>b", "a b"},
+		{"strip", "1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"strip", "x<!-- note -->y<!DOCTYPE html>", "xy"},
+		{"strip", `<img src=x onerror="alert(1)">caption`, "caption"},
+		{"strip", "<SCRIPT>bad()</ScRiPt>ok", "ok"},
+		{"strip", "unclosed <b", "unclosed"},
+		{"strip", "&lt;script&gt;alert(1)&lt;/script&gt;", ""},
+		{"strip", "x &lt;b&gt;y&lt;/b&gt; &lt;!-- z --&gt;", "x y"},
+		{"strip", "&amp;lt;script&amp;gt;", "&lt;script&gt;"},
+		{"strip", "1 &lt; 2 &amp;&amp; 3 &gt; 2", "1 < 2 && 3 > 2"},
+		{"escape", `<b>"hi"</b> & bye`, "&lt;b&gt;&#34;hi&#34;&lt;/b&gt; &amp; bye"},
+		{"basic", "<b>bold</b> <em>em</em>", "<b>bold</b> <em>em</em>"},
+		{"basic", `<p class="x" onclick="y()">para`, "<p>para</p>"},
+		{"basic", "<div><b>in</b></div><style>p{}</style>", "<b>in</b>"},
+		{"basic", "<ul><li>one<li>two</ul>", "<ul><li>one<li>two</li></li></ul>"},
+		{"basic", "line<br/>break</br>", "line<br>break"},
+		{"basic", "<b>a <i>b</b> c</i>", "<b>a <i>b</i></b> c"},
+		{"basic", "Tom &amp; Jerry > 3", "Tom &amp; Jerry &gt; 3"},
+		{"basic", `<a href="https://example.com">link</a>`, "link"},
+		{"links", `<a href="https://example.com" title='a"b'>link</a>`, `<a href="https://example.com" title="a&#34;b">link</a>`},
+		{"links", `<a href="/relative?q=1">link</a>`, `<a href="/relative?q=1">link</a>`},
+		{"links", `<a href="java&#x09;script:alert(1)" title="t">link</a>`, `<a title="t">link</a>`},
+		{"links", `<a href="JavaScript:alert(1)">link</a>`, "<a>link</a>"},
+		{"links", `<a href="https://x.com/?a=1&amp;b=2" title="Tom &amp; Jerry">link</a>`, `<a href="https://x.com/?a=1&amp;b=2" title="Tom &amp; Jerry">link</a>`},
+		{"links", `<a href="https://x.com/?a=1&b=2" title='&quot;hi&quot; &lt;3'>link</a>`, `<a href="https://x.com/?a=1&amp;b=2" title="&#34;hi&#34; &lt;3">link</a>`},
+	}
+	for _, c := range cases {
+		inputs.Strip, inputs.Escape, inputs.Basic, inputs.Links = String{}, String{}, String{}, String{}
+		e := d.DecodeValues(&inputs, url.Values{c.field: {c.in}})
+		assertEqual(t, e, ErrorHash(nil))
+
+		got := map[string]string{
+			"strip":  inputs.Strip.Val,
+			"escape": inputs.Escape.Val,
+			"basic":  inputs.Basic.Val,
+			"links":  inputs.Links.Val,
+		}[c.field]
+		assertEqual(t, got, c.out)
+	}
+
+	// markup removed entirely leaves a blank value
+	var required struct {
+		Bio String `meta_sanitize:"strip_html" meta_required:"true"`
+	}
+	e := NewDecoder(&required).DecodeValues(&required, url.Values{"bio": {"<script>x</script> "}})
+	assertEqual(t, e, ErrorHash{"bio": ErrBlank})
+}
+
+func TestStringSanitizeError(t *testing.T) {
+	var inputs struct {
+		Name String `meta_sanitize:"strip_html" meta_sanitize_mode:"error"`
+		Bio  String `meta_sanitize:"allowlist:basic" meta_sanitize_mode:"error"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{
+		"name": {"Tom & Jerry <3"},
+		"bio":  {"<p>Hello <b>there</b></p>"},
+	})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Name.Val, "Tom & Jerry <3")
+	assertEqual(t, inputs.Bio.Val, "<p>Hello <b>there</b></p>")
+
+	e = d.DecodeValues(&inputs, url.Values{
+		"name": {"<b>Tom</b>"},
+		"bio":  {`<p onclick="x()">Hello</p>`},
+	})
+	assertEqual(t, e, ErrorHash{"name": ErrMarkup, "bio": ErrMarkup})
+
+	e = d.DecodeValues(&inputs, url.Values{"name": {"&lt;script&gt;alert(1)&lt;/script&gt;"}})
+	assertEqual(t, e, ErrorHash{"name": ErrMarkup})
+
+	e = d.DecodeValues(&inputs, url.Values{"name": {"Tom &amp; Jerry &lt;3"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Name.Val, "Tom & Jerry <3")
+}
+
+func TestSanitizePolicyRegistration(t *testing.T) {
+	RegisterSanitizePolicy("test_code", &SanitizePolicy{
+		Elements: map[string][]string{"code": nil},
+	})
+
+	var inputs struct {
+		Snippet String `meta_sanitize:"allowlist:test_code"`
+	}
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"snippet": {"<b>x</b> <code>y</code>"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Snippet.Val, "x <code>y</code>")
+
+	assertPanics := func(s interface{}) {
+		defer func() {
+			assert(t, recover() != nil, "expected a panic")
+		}()
+		NewDecoder(s)
+	}
+	assertPanics(&struct {
+		A String `meta_sanitize:"allowlist:missing"`
+	}{})
+	assertPanics(&struct {
+		A String `meta_sanitize:"markdown"`
+	}{})
+	assertPanics(&struct {
+		A String `meta_sanitize:"strip_html" meta_sanitize_mode:"warn"`
+	}{})
+}
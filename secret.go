@@ -0,0 +1,285 @@
+package meta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//
+// Secret
+//
+
+// Secret is a String for passwords, tokens and other credentials. Its String, GoString and
+// MarshalJSON methods and every fmt verb print SecretRedacted instead of the value, so decoded
+// structs can be logged. Val and Value, for storage, are the only ways to read it.
+//
+// fmt can't call methods through unexported struct fields, so it prints Val in plain text for a
+// Secret held in an unexported field, eg struct{ password meta.Secret }. Decoded fields are always
+// exported; copy a Secret elsewhere only into exported fields.
+type Secret struct {
+	Val string
+	Nullity
+	Presence
+	Path string
+}
+
+// SecretRedacted is printed in place of a Secret value.
+const SecretRedacted = "[redacted]"
+
+type SecretOptions struct {
+	Required        bool
+	DiscardBlank    bool
+	Null            bool
+	MinRunesPresent bool
+	MinRunes        int
+	MaxRunesPresent bool
+	MaxRunes        int
+	// Classes are the character classes the value must contain: "lower", "upper", "digit" and
+	// "symbol", which is anything else.
+	// Configured via meta_require tag, eg `meta_require:"lower,upper,digit"`.
+	Classes []string
+	// Denylist is a list of values rejected with ErrCommonSecret, ignoring case. "common" is built in,
+	// other lists are added with RegisterSecretDenylist.
+	// Configured via meta_denylist tag, eg `meta_denylist:"common"`.
+	Denylist map[string]bool
+}
+
+var secretClasses = map[string]func(rune) bool{
+	"lower":  unicode.IsLower,
+	"upper":  unicode.IsUpper,
+	"digit":  unicode.IsDigit,
+	"symbol": func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
+}
+
+var (
+	secretDenylistsMu sync.RWMutex
+	secretDenylists   = map[string]map[string]bool{
+		// common holds the most frequent passwords of public breach corpora.
+		"common": secretDenylist([]string{
+			"123456", "123456789", "12345678", "12345", "1234567", "1234567890", "111111", "000000",
+			"123123", "654321", "666666", "121212", "112233", "123321", "qwerty", "qwerty123",
+			"qwertyuiop", "1q2w3e4r", "1qaz2wsx", "asdfghjkl", "zxcvbnm", "password", "password1",
+			"password123", "passw0rd", "p@ssw0rd", "admin", "admin123", "welcome", "welcome1",
+			"letmein", "iloveyou", "abc123", "monkey", "dragon", "football", "baseball", "sunshine",
+			"princess", "master", "shadow", "superman", "michael", "trustno1", "login", "starwars",
+			"secret", "changeme", "default", "guest", "test", "test123", "root", "toor",
+		}),
+	}
+)
+
+// RegisterSecretDenylist makes values available to meta_denylist:"<name>" tags. It is meant to be
+// called at init time, before decoders using the list are created.
+func RegisterSecretDenylist(name string, values []string) {
+	secretDenylistsMu.Lock()
+	defer secretDenylistsMu.Unlock()
+	secretDenylists[name] = secretDenylist(values)
+}
+
+func secretDenylist(values []string) map[string]bool {
+	list := make(map[string]bool, len(values))
+	for _, v := range values {
+		list[strings.ToLower(v)] = true
+	}
+	return list
+}
+
+func NewSecret(s string) Secret {
+	return Secret{s, Nullity{false}, Presence{true}, ""}
+}
+
+func (s *Secret) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &SecretOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+	}
+
+	if minRunesString := tag.Get("meta_min_runes"); minRunesString != "" {
+		minRunes, err := strconv.ParseInt(minRunesString, 10, 0)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		opts.MinRunesPresent = true
+		opts.MinRunes = int(minRunes)
+	}
+
+	if maxRunesString := tag.Get("meta_max_runes"); maxRunesString != "" {
+		maxRunes, err := strconv.ParseInt(maxRunesString, 10, 0)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		opts.MaxRunesPresent = true
+		opts.MaxRunes = int(maxRunes)
+	}
+
+	for _, class := range parseLowerList(tag.Get("meta_require")) {
+		if _, ok := secretClasses[class]; !ok {
+			panic("invalid meta_require " + class)
+		}
+		opts.Classes = append(opts.Classes, class)
+	}
+
+	if name := tag.Get("meta_denylist"); name != "" {
+		secretDenylistsMu.RLock()
+		list, ok := secretDenylists[name]
+		secretDenylistsMu.RUnlock()
+		if !ok {
+			panic(fmt.Sprintf("meta: unknown secret denylist %q", name))
+		}
+		opts.Denylist = list
+	}
+
+	return opts
+}
+
+func (s *Secret) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	s.Path = path
+	if i == nil {
+		opts := options.(*SecretOptions)
+		if opts.Null {
+			s.Present = true
+			s.Null = true
+			return nil
+		}
+		return s.FormValue("", options)
+	}
+
+	if value, ok := i.(string); ok {
+		return s.FormValue(value, options)
+	}
+	return ErrString
+}
+
+// FormValue takes the value as is: unlike String, surrounding whitespace is kept.
+func (s *Secret) FormValue(value string, options interface{}) Errorable {
+	if !utf8.ValidString(value) {
+		return ErrUtf8
+	}
+
+	opts := options.(*SecretOptions)
+
+	if value == "" {
+		if opts.Null {
+			s.Present = true
+			s.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			s.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	runeCount := utf8.RuneCountInString(value)
+	if opts.MinRunesPresent && runeCount < opts.MinRunes {
+		return ErrMinRunes
+	}
+	if opts.MaxRunesPresent && runeCount > opts.MaxRunes {
+		return ErrMaxRunes
+	}
+
+	for _, class := range opts.Classes {
+		if strings.IndexFunc(value, secretClasses[class]) < 0 {
+			return ErrSecretClass
+		}
+	}
+
+	if opts.Denylist[strings.ToLower(value)] {
+		return ErrCommonSecret
+	}
+
+	s.Val = value
+	s.Present = true
+	return nil
+}
+
+// Equal reports whether s holds value, taking the same time wherever they differ.
+func (s Secret) Equal(value string) bool {
+	return s.Present && !s.Null && ConstantTimeEqual(s.Val, value)
+}
+
+// ConstantTimeEqual compares a and b in a time that depends on neither their contents nor their
+// lengths, for checking tokens and other secrets.
+func ConstantTimeEqual(a, b string) bool {
+	ha := sha256.Sum256([]byte(a))
+	hb := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+}
+
+func (s Secret) String() string {
+	return SecretRedacted
+}
+
+func (s Secret) GoString() string {
+	return "meta.Secret{" + SecretRedacted + "}"
+}
+
+// Format prints SecretRedacted for every verb, including %#v and %+v of structs holding s in an
+// exported field.
+func (s Secret) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, s.GoString())
+		return
+	}
+	fmt.Fprint(f, SecretRedacted)
+}
+
+func (s Secret) Value() (driver.Value, error) {
+	if s.Present && !s.Null {
+		return s.Val, nil
+	}
+	return nil, nil
+}
+
+func (s *Secret) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*s = Secret{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case string:
+		*s = NewSecret(value)
+		return nil
+	case []byte:
+		*s = NewSecret(string(value))
+		return nil
+	}
+	return fmt.Errorf("meta: cannot scan %T into Secret", src)
+}
+
+// MarshalJSON writes SecretRedacted for a present value, so that responses and logs of decoded
+// structs don't leak it.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	if s.Present && !s.Null {
+		return MetaJson.Marshal(SecretRedacted)
+	}
+	return nullString, nil
+}
+
+func (s *Secret) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		s.Nullity = Nullity{true}
+		return nil
+	}
+
+	var value string
+	if err := MetaJson.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*s = NewSecret(value)
+	return nil
+}
@@ -0,0 +1,159 @@
+package meta
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSecretSuccess(t *testing.T) {
+	var inputs struct {
+		Password Secret `meta_required:"true"`
+		Token    Secret
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"password": {" hunter2 "}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Password.Val, " hunter2 ")
+	assertEqual(t, inputs.Password.Present, true)
+	assertEqual(t, inputs.Token.Present, false)
+
+	e = d.DecodeJSON(&inputs, []byte(`{"password": "s3cret", "token": "abc"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Password.Val, "s3cret")
+	assertEqual(t, inputs.Token.Val, "abc")
+}
+
+func TestSecretFailure(t *testing.T) {
+	var inputs struct {
+		Password Secret `meta_required:"true" meta_min_runes:"8" meta_max_runes:"64" meta_require:"lower, upper,digit"`
+		Pin      Secret `meta_require:"digit" meta_denylist:"common"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"pin": {"1234x"}})
+	assertEqual(t, e, ErrorHash{"password": ErrRequired})
+
+	e = d.DecodeValues(&inputs, url.Values{"password": {""}})
+	assertEqual(t, e, ErrorHash{"password": ErrBlank})
+
+	cases := []struct {
+		password string
+		err      Errorable
+	}{
+		{"Ab1", ErrMinRunes},
+		{strings.Repeat("Ab1", 22), ErrMaxRunes},
+		{"abcdefgh1", ErrSecretClass},
+		{"ABCDEFGH1", ErrSecretClass},
+		{"Abcdefghi", ErrSecretClass},
+		{"Abcdefgh1", nil},
+	}
+	for _, c := range cases {
+		e = d.DecodeValues(&inputs, url.Values{"password": {c.password}})
+		if c.err == nil {
+			assertEqual(t, e, ErrorHash(nil), c.password)
+		} else {
+			assertEqual(t, e, ErrorHash{"password": c.err}, c.password)
+		}
+	}
+
+	e = d.DecodeValues(&inputs, url.Values{"password": {"Abcdefgh1"}, "pin": {"123456"}})
+	assertEqual(t, e, ErrorHash{"pin": ErrCommonSecret})
+
+	e = d.DecodeJSON(&inputs, []byte(`{"password": 12345678}`))
+	assertEqual(t, e, ErrorHash{"password": ErrString})
+}
+
+func TestSecretDenylist(t *testing.T) {
+	RegisterSecretDenylist("test_products", []string{"Acme", "AcmeCorp2024"})
+
+	var inputs struct {
+		Password Secret `meta_denylist:"test_products"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"password": {"acmecorp2024"}})
+	assertEqual(t, e, ErrorHash{"password": ErrCommonSecret})
+
+	e = d.DecodeValues(&inputs, url.Values{"password": {"password"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	defer func() {
+		assert(t, recover() != nil, "expected a panic")
+	}()
+	NewDecoder(&struct {
+		Password Secret `meta_denylist:"missing"`
+	}{})
+}
+
+func TestSecretRedaction(t *testing.T) {
+	type login struct {
+		Email    String
+		Password Secret
+	}
+	l := login{Email: NewString("a@example.com"), Password: NewSecret("hunter2")}
+
+	for _, out := range []string{
+		l.Password.String(),
+		fmt.Sprint(l.Password),
+		fmt.Sprintf("%s %q %x %v %+v %#v", l.Password, l.Password, l.Password, l.Password, l.Password, l.Password),
+		fmt.Sprintf("%v %+v %#v", l, l, l),
+		fmt.Sprintf("%v", &l),
+		fmt.Sprint([]Secret{l.Password}),
+	} {
+		assert(t, !strings.Contains(out, "hunter2"), out)
+		assert(t, strings.Contains(out, SecretRedacted), out)
+	}
+
+	bs, err := MetaJson.Marshal(l)
+	assertEqual(t, err, nil)
+	assert(t, !strings.Contains(string(bs), "hunter2"), string(bs))
+
+	bs, err = MetaJson.Marshal(Secret{})
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "null")
+
+	// fmt skips the methods of values in unexported fields, so those print Val, as documented
+	type session struct {
+		Token  Secret
+		secret Secret
+	}
+	out := fmt.Sprintf("%+v", session{Token: NewSecret("t0ken"), secret: NewSecret("hunter2")})
+	assert(t, !strings.Contains(out, "t0ken"), out)
+	assert(t, strings.Contains(out, "hunter2"), out)
+}
+
+func TestSecretEqual(t *testing.T) {
+	s := NewSecret("token-123")
+	assertEqual(t, s.Equal("token-123"), true)
+	assertEqual(t, s.Equal("token-124"), false)
+	assertEqual(t, s.Equal("token-12"), false)
+	assertEqual(t, Secret{}.Equal(""), false)
+
+	assertEqual(t, ConstantTimeEqual("a", "a"), true)
+	assertEqual(t, ConstantTimeEqual("", ""), true)
+	assertEqual(t, ConstantTimeEqual("a", "ab"), false)
+}
+
+func TestSecretSQL(t *testing.T) {
+	v, err := NewSecret("hunter2").Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "hunter2")
+
+	v, err = Secret{}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, nil)
+
+	var s Secret
+	assertEqual(t, s.Scan([]byte("hunter2")), nil)
+	assertEqual(t, s.Val, "hunter2")
+	assertEqual(t, s.Scan(nil), nil)
+	assertEqual(t, s.Null, true)
+	assert(t, s.Scan(42) != nil)
+
+	assertEqual(t, s.UnmarshalJSON([]byte(`"from-config"`)), nil)
+	assertEqual(t, s.Val, "from-config")
+	assertEqual(t, s.Present, true)
+}
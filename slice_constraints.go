@@ -0,0 +1,251 @@
+package meta
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+//
+// Uniqueness and ordering of slices
+//
+
+// parseSliceConstraints reads meta_unique, meta_key, meta_sorted and meta_normalize into opts.
+func parseSliceConstraints(opts *SliceOptions, tag reflect.StructTag) {
+	switch unique := tag.Get("meta_unique"); unique {
+	case "", "false":
+	case "true":
+		opts.Unique = true
+	case "case_insensitive":
+		opts.Unique = true
+		opts.UniqueCaseInsensitive = true
+	default:
+		panic("invalid meta_unique " + unique)
+	}
+
+	switch sorted := tag.Get("meta_sorted"); sorted {
+	case "":
+	case "asc", "desc":
+		opts.Sorted = sorted
+	default:
+		panic("invalid meta_sorted " + sorted)
+	}
+
+	opts.Key = tag.Get("meta_key")
+	opts.Normalize = tag.Get("meta_normalize") == "true"
+}
+
+// constrained reports whether the slice has a uniqueness or ordering constraint.
+func (opts *SliceOptions) constrained() bool {
+	return opts.Unique || opts.Sorted != ""
+}
+
+// constrain checks the Unique and Sorted options on slice, a reflect slice value, comparing the
+// elements by key. With Normalize, duplicates are dropped, keeping the first one, and the slice is
+// sorted instead. Otherwise duplicates are reported with ErrUnique at their index in an
+// ErrorSlice, and an unsorted slice with ErrSorted. Nil keys, from absent values, are never
+// duplicates and sort first.
+func (opts *SliceOptions) constrain(slice reflect.Value, key func(reflect.Value) interface{}) (reflect.Value, Errorable) {
+	if !opts.constrained() {
+		return slice, nil
+	}
+
+	keys := make([]interface{}, slice.Len())
+	for i := range keys {
+		keys[i] = opts.sliceKey(key(slice.Index(i)))
+	}
+
+	if opts.Unique {
+		seen := make(map[interface{}]bool, len(keys))
+		duplicates := make(ErrorSlice, len(keys))
+		kept := reflect.MakeSlice(slice.Type(), 0, slice.Len())
+		var keptKeys []interface{}
+		for i, k := range keys {
+			// like NULL in a SQL unique index, a missing key never collides
+			if k != nil && seen[k] {
+				duplicates[i] = ErrUnique
+				continue
+			}
+			seen[k] = true
+			kept = reflect.Append(kept, slice.Index(i))
+			keptKeys = append(keptKeys, k)
+		}
+
+		if duplicates.Len() > 0 {
+			if !opts.Normalize {
+				return slice, duplicates
+			}
+			slice, keys = kept, keptKeys
+		}
+	}
+
+	if opts.Sorted != "" {
+		less := func(i, j int) bool {
+			if opts.Sorted == "desc" {
+				return compareSliceKeys(keys[i], keys[j]) > 0
+			}
+			return compareSliceKeys(keys[i], keys[j]) < 0
+		}
+
+		if !sort.SliceIsSorted(keys, less) {
+			if !opts.Normalize {
+				return slice, ErrSorted
+			}
+
+			order := make([]int, len(keys))
+			for i := range order {
+				order[i] = i
+			}
+			sort.SliceStable(order, func(a, b int) bool { return less(order[a], order[b]) })
+
+			sorted := reflect.MakeSlice(slice.Type(), 0, slice.Len())
+			for _, i := range order {
+				sorted = reflect.Append(sorted, slice.Index(i))
+			}
+			slice = sorted
+		}
+	}
+
+	return slice, nil
+}
+
+// sliceKey returns a comparable form of an element key: driver values of meta types are unwrapped,
+// times are compared as instants and strings are folded for UniqueCaseInsensitive.
+func (opts *SliceOptions) sliceKey(k interface{}) interface{} {
+	if valuer, ok := k.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return fmt.Sprint(k)
+		}
+		k = v
+	}
+
+	switch value := k.(type) {
+	case []byte:
+		k = string(value)
+	case time.Time:
+		return value.UnixNano()
+	case int:
+		return int64(value)
+	}
+
+	if s, ok := k.(string); ok && opts.UniqueCaseInsensitive {
+		return strings.ToLower(s)
+	}
+	if k != nil && !reflect.TypeOf(k).Comparable() {
+		return fmt.Sprint(k)
+	}
+	return k
+}
+
+// compareSliceKeys orders keys of the same type. nil sorts first, and keys of different types are
+// compared by their text.
+func compareSliceKeys(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	case int64:
+		if y, ok := b.(int64); ok {
+			return compareOrdered(x, y)
+		}
+	case uint64:
+		if y, ok := b.(uint64); ok {
+			return compareOrdered(x, y)
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrdered(x, y)
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareOrdered[T int64 | uint64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// constrainValues applies the slice constraints to the Val of StringSlice, Int64Slice and the other
+// slice types.
+func constrainValues[T any](vals []T, opts *SliceOptions) ([]T, Errorable) {
+	slice, err := opts.constrain(reflect.ValueOf(vals), reflect.Value.Interface)
+	if err != nil {
+		return vals, err
+	}
+	return slice.Interface().([]T), nil
+}
+
+// resolveKey finds the field named by meta_key in the elements of a categorySliceOfStructs field,
+// panicking when there is none.
+func (d *DecoderField) resolveKey() {
+	if d.fieldCategory != categorySliceOfStructs || d.Key == "" {
+		return
+	}
+
+	for _, field := range d.StructDecoder.Fields {
+		if field.Name == d.Key {
+			d.keyIndex = field.fieldIndex
+			return
+		}
+	}
+	panic(fmt.Sprintf("meta: meta_key %q is not a field of %s", d.Key, d.elemIndirectedType))
+}
+
+// elementKey returns the key of an element of a categorySliceOfValues or categorySliceOfStructs
+// field: the element itself, or the value of its Key field.
+func (d *DecoderField) elementKey(elem reflect.Value) interface{} {
+	elem = reflect.Indirect(elem)
+	if d.fieldCategory != categorySliceOfStructs || !elem.IsValid() {
+		return valuerOrValue(elem)
+	}
+	return valuerOrValue(elem.FieldByIndex(d.keyIndex))
+}
+
+// valuerOrValue returns v as a driver.Valuer when its pointer is one, like the meta types, and
+// as a plain value otherwise. Nil pointers, eg an optional *meta.Int64 key, give nil.
+func valuerOrValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanAddr() {
+		if valuer, ok := v.Addr().Interface().(driver.Valuer); ok {
+			return valuer
+		}
+	}
+	return v.Interface()
+}
@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -276,3 +278,117 @@ func TestSliceOfHashesLength(t *testing.T) {
 	}`))
 	assertEqual(t, e, ErrorHash{"a": ErrMaxLength})
 }
+
+func TestSliceUnique(t *testing.T) {
+	var inputs struct {
+		A []Int64   `meta_unique:"true"`
+		B []*String `meta_unique:"case_insensitive"`
+		C []Time    `meta_unique:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"a":[1,2,3],"b":["x","Y"],"c":["2024-01-01T00:00:00Z","2024-01-01T01:00:00+02:00"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, len(inputs.A), 3)
+
+	e = d.DecodeJSON(&inputs, []byte(`{"a":[1,2,1,1],"b":["x","X"],"c":["2024-01-01T00:00:00Z","2024-01-01T02:00:00+02:00"]}`))
+	assertEqual(t, e, ErrorHash{
+		"a": ErrorSlice{nil, nil, ErrUnique, ErrUnique},
+		"b": ErrorSlice{nil, ErrUnique},
+		"c": ErrorSlice{nil, ErrUnique},
+	})
+}
+
+func TestSliceSorted(t *testing.T) {
+	var inputs struct {
+		A []Int64  `meta_sorted:"asc"`
+		B []String `meta_sorted:"desc"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"a":[1,1,5],"b":["c","b","a"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeJSON(&inputs, []byte(`{"a":[2,1],"b":["a","b"]}`))
+	assertEqual(t, e, ErrorHash{"a": ErrSorted, "b": ErrSorted})
+}
+
+func TestSliceNormalize(t *testing.T) {
+	var inputs struct {
+		A []Int64 `meta_unique:"true" meta_sorted:"asc" meta_normalize:"true" meta_max_length:"3"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"a":[3,1,3,2,1]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, len(inputs.A), 3)
+	for i, v := range inputs.A {
+		assertEqual(t, v.Val, int64(i+1))
+	}
+	// the path still points at the element in the input
+	assertEqual(t, inputs.A[0].Path, "a.1")
+
+	e = d.DecodeJSON(&inputs, []byte(`{"a":[4,3,2,1]}`))
+	assertEqual(t, e, ErrorHash{"a": ErrMaxLength})
+}
+
+func TestSliceOfStructsUnique(t *testing.T) {
+	type item struct {
+		ID    Int64 `meta:"id" meta_required:"true"`
+		Email String
+	}
+	var inputs struct {
+		Items  []item  `meta_unique:"true" meta_key:"id"`
+		Emails []*item `meta_unique:"case_insensitive" meta_key:"email" meta_sorted:"asc" meta_normalize:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"items":[{"id":1},{"id":2},{"id":1}],"emails":[{"id":1,"email":"b@x.com"},{"id":2,"email":"a@x.com"},{"id":3,"email":"B@x.com"}]}`))
+	assertEqual(t, e, ErrorHash{"items": ErrorSlice{nil, nil, ErrUnique}})
+	assertEqual(t, len(inputs.Emails), 2)
+	assertEqual(t, inputs.Emails[0].ID.Val, int64(2))
+	assertEqual(t, inputs.Emails[1].ID.Val, int64(1))
+
+	defer func() {
+		assert(t, recover() != nil)
+	}()
+	var missingKey struct {
+		Items []item `meta_unique:"true"`
+	}
+	NewDecoder(&missingKey)
+}
+
+func TestSliceOfStructsOptionalKey(t *testing.T) {
+	type item struct {
+		Key  *Int64
+		Name String
+	}
+	var inputs struct {
+		Items  []item `meta_unique:"true" meta_key:"key"`
+		Sorted []item `meta_sorted:"asc" meta_key:"key"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"items":[{"name":"a"},{"name":"b"}],"sorted":[{"name":"a"},{"key":1},{"key":2}]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, len(inputs.Items), 2)
+
+	e = d.DecodeJSON(&inputs, []byte(`{"items":[{"name":"a"},{"key":1},{"key":1}],"sorted":[{"key":1},{"name":"a"}]}`))
+	assertEqual(t, e, ErrorHash{"items": ErrorSlice{nil, nil, ErrUnique}, "sorted": ErrSorted})
+}
+
+func TestSliceOfStructsUnknownKey(t *testing.T) {
+	type item struct {
+		ID Int64 `meta:"id"`
+	}
+
+	defer func() {
+		r := recover()
+		assert(t, r != nil, "expected NewDecoder to panic")
+		assert(t, strings.Contains(fmt.Sprint(r), `meta_key "ident"`), r)
+	}()
+	var inputs struct {
+		Items []item `meta_unique:"true" meta_key:"ident"`
+	}
+	NewDecoder(&inputs)
+}
@@ -0,0 +1,195 @@
+package meta
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// SQL encodings of slices
+//
+
+const (
+	// SQLFormatArray stores slices as Postgres array literals, eg `{a,"b c"}`, for text[], bigint[]
+	// and other array columns.
+	SQLFormatArray = "array"
+	// SQLFormatJSON stores slices as JSON arrays, for json and jsonb columns.
+	SQLFormatJSON = "json"
+)
+
+// parseSQLFormat returns the SQL format named by a meta_sql_format tag. "jsonb" is accepted for
+// SQLFormatJSON.
+func parseSQLFormat(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", SQLFormatArray:
+		return SQLFormatArray
+	case SQLFormatJSON, "jsonb":
+		return SQLFormatJSON
+	}
+	panic("invalid meta_sql_format " + name)
+}
+
+// SliceValue encodes the driver values of elems, eg a []meta.Time field, as a Postgres array
+// literal or a JSON array depending on format, "" being SQLFormatArray. Absent and null elements
+// are stored as NULL.
+func SliceValue[T driver.Valuer](elems []T, format string) (driver.Value, error) {
+	values := make([]driver.Value, len(elems))
+	for i, elem := range elems {
+		v, err := elem.Value()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return encodeSQLSlice(values, format)
+}
+
+// ScanSlice decodes a Postgres array or a JSON array, as written by SliceValue, scanning each
+// element into a T, eg ScanSlice[meta.Time](src). A NULL src gives a nil slice.
+func ScanSlice[T any, P interface {
+	*T
+	sql.Scanner
+}](src interface{}) ([]T, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	values, err := decodeSQLSlice(src)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]T, len(values))
+	for i, v := range values {
+		if err := P(&elems[i]).Scan(v); err != nil {
+			return nil, fmt.Errorf("meta: element %d: %w", i, err)
+		}
+	}
+	return elems, nil
+}
+
+// encodeSQLSlice encodes driver values in format.
+func encodeSQLSlice(values []driver.Value, format string) (driver.Value, error) {
+	if format == SQLFormatJSON {
+		elems := make([]interface{}, len(values))
+		for i, v := range values {
+			switch value := v.(type) {
+			case []byte:
+				elems[i] = string(value)
+			case time.Time:
+				elems[i] = value.Format(time.RFC3339Nano)
+			default:
+				elems[i] = value
+			}
+		}
+		bs, err := MetaJson.Marshal(elems)
+		if err != nil {
+			return nil, err
+		}
+		return string(bs), nil
+	}
+
+	elems := make([]string, len(values))
+	nulls := make([]bool, len(values))
+	for i, v := range values {
+		switch value := v.(type) {
+		case nil:
+			nulls[i] = true
+		case string:
+			elems[i] = value
+		case []byte:
+			elems[i] = string(value)
+		case int64:
+			elems[i] = strconv.FormatInt(value, 10)
+		case float64:
+			elems[i] = strconv.FormatFloat(value, 'g', -1, 64)
+		case bool:
+			elems[i] = strconv.FormatBool(value)
+		case time.Time:
+			elems[i] = value.Format(time.RFC3339Nano)
+		default:
+			return nil, fmt.Errorf("meta: cannot store %T in a postgres array", v)
+		}
+	}
+	return formatPostgresArray(elems, nulls), nil
+}
+
+// decodeSQLSlice splits a Postgres array or a JSON array into values for the Scan methods: nil for
+// NULL, bool for JSON booleans and strings for everything else.
+func decodeSQLSlice(src interface{}) ([]interface{}, error) {
+	var s string
+	switch value := src.(type) {
+	case string:
+		s = value
+	case []byte:
+		s = string(value)
+	default:
+		return nil, fmt.Errorf("meta: cannot scan %T into a slice", src)
+	}
+
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		if values, ok := decodeJSONSlice(s); ok {
+			return values, nil
+		}
+	}
+
+	elems, nulls, err := splitPostgresArray(s)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(elems))
+	for i, elem := range elems {
+		if !nulls[i] {
+			values[i] = elem
+		}
+	}
+	return values, nil
+}
+
+// decodeJSONSlice reads a JSON array of scalars. It reports false for anything else, which includes
+// Postgres arrays with a dimension decoration like "[1:2]={a,b}".
+func decodeJSONSlice(s string) ([]interface{}, bool) {
+	var raw []interface{}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil || dec.More() {
+		return nil, false
+	}
+
+	values := make([]interface{}, len(raw))
+	for i, v := range raw {
+		switch value := v.(type) {
+		case nil, string, bool:
+			values[i] = value
+		case json.Number:
+			values[i] = value.String()
+		default:
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// scanSliceStrings decodes src for StringSlice and Int64Slice, which can't hold NULL elements.
+func scanSliceStrings(src interface{}) ([]string, error) {
+	values, err := decodeSQLSlice(src)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]string, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("meta: cannot scan %v element into a slice", v)
+		}
+		elems[i] = s
+	}
+	return elems, nil
+}
@@ -0,0 +1,109 @@
+package meta
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestStringSliceValue(t *testing.T) {
+	s := StringSlice{Val: []string{"a", "b c", `d"e`, `f\g`, "", "NULL", "{h}"}}
+	v, err := s.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, `{a,"b c","d\"e","f\\g","","NULL","{h}"}`)
+
+	var scanned StringSlice
+	err = scanned.Scan([]byte(v.(string)))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, s.Val)
+
+	v, err = s.WithSQLFormat("jsonb").Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, `["a","b c","d\"e","f\\g","","NULL","{h}"]`)
+
+	scanned = StringSlice{}.WithSQLFormat(SQLFormatJSON)
+	err = scanned.Scan(v)
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, s.Val)
+	v, err = scanned.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, `["a","b c","d\"e","f\\g","","NULL","{h}"]`)
+
+	v, err = StringSlice{Val: []string{}}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{}")
+
+	v, err = StringSlice{}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, nil)
+
+	err = scanned.Scan(`["a",null]`)
+	assert(t, err != nil)
+}
+
+func TestInt64SliceValue(t *testing.T) {
+	var inputs struct {
+		A Int64Slice
+		B Int64Slice `meta_sql_format:"json"`
+	}
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"a": {"1,2,3"}, "b": {"4,5"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	v, err := inputs.A.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{1,2,3}")
+
+	v, err = inputs.B.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "[4,5]")
+
+	var scanned Int64Slice
+	err = scanned.Scan([]byte("[4, 5]"))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []int64{4, 5})
+
+	err = scanned.Scan(`["4"]`)
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, []int64{4})
+
+	err = scanned.Scan("[true]")
+	assert(t, err != nil)
+}
+
+func TestSliceValue(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	times := []Time{NewTime(at), {Nullity: Nullity{true}, Presence: Presence{true}}}
+
+	v, err := SliceValue(times, "")
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{2024-03-05T14:30:00Z,NULL}")
+
+	scanned, err := ScanSlice[Time](v)
+	assertEqual(t, err, nil)
+	assertEqual(t, len(scanned), 2)
+	assert(t, scanned[0].Val.Equal(at))
+	assertEqual(t, scanned[1].Null, true)
+
+	scanned, err = ScanSlice[Time]("{\"2024-03-05 14:30:00+00\"}")
+	assertEqual(t, err, nil)
+	assert(t, scanned[0].Val.Equal(at))
+
+	v, err = SliceValue([]Float64{NewFloat64(1.5), {}}, SQLFormatJSON)
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "[1.5,null]")
+
+	floats, err := ScanSlice[Float64](v)
+	assertEqual(t, err, nil)
+	assertEqual(t, floats, []Float64{NewFloat64(1.5), {Nullity: Nullity{true}, Presence: Presence{true}}})
+
+	bools, err := ScanSlice[Bool]([]byte("{t,f}"))
+	assertEqual(t, err, nil)
+	assertEqual(t, bools, []Bool{NewBool(true), NewBool(false)})
+
+	_, err = ScanSlice[Int64]("{1,x}")
+	assert(t, err != nil)
+
+	nothing, err := ScanSlice[Int64](nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, nothing, []Int64(nil))
+}
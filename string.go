@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -35,6 +37,55 @@ type StringOptions struct {
 	MaxBytesPresent bool
 	MaxBytes        int
 	In              []string
+	// Pattern is a regular expression the whole value must match, as with the HTML pattern attribute.
+	// Configured via meta_pattern tag, compiled once when the decoder is created.
+	// Example: `meta_pattern:"[A-Z]{2}-\\d{4}"`
+	Pattern *regexp.Regexp
+	// Charset restricts the value to a predefined class: "alnum", "slug" (lowercase words joined by
+	// dashes), "hex" or "ascii_printable".
+	// Configured via meta_charset tag.
+	Charset string
+
+	// The normalizations below run in this order, before Strip and every check, see normalize.
+
+	// StripControl removes control characters other than tab and newlines, and every format character
+	// (Unicode category Cf): zero-width characters, bidi overrides and isolates, soft hyphens and the
+	// like, which make lookalike values. Joiners go too, so emoji sequences are split.
+	// Configured via meta_strip_control tag.
+	StripControl bool
+	// UnicodeForm normalizes the value to "nfc" or "nfkc". NFKC also folds compatibility
+	// characters, eg fullwidth letters and ligatures, to their plain form.
+	// Configured via meta_unicode tag.
+	UnicodeForm string
+	// CollapseSpace replaces runs of whitespace with a single space.
+	// Configured via meta_collapse_space tag.
+	CollapseSpace bool
+	// Trim, TrimLeft and TrimRight are cutsets removed from both ends, the start or the end of the
+	// value, after Strip.
+	// Configured via meta_trim, meta_trim_left and meta_trim_right tags, eg `meta_trim:"./"`.
+	Trim      string
+	TrimLeft  string
+	TrimRight string
+	// Case converts the value to "lower", "upper" or "title" case, last.
+	// Configured via meta_case tag.
+	Case string
+
+	// Sanitize removes markup from the value, after the normalizations: "strip_html" keeps only the
+	// text, "escape_html" escapes it and "allowlist" keeps what SanitizePolicy allows.
+	// Configured via meta_sanitize tag, eg `meta_sanitize:"allowlist:basic"`, see RegisterSanitizePolicy.
+	Sanitize       string
+	SanitizePolicy *SanitizePolicy
+	// SanitizeStrict reports ErrMarkup instead of cleaning a value with markup Sanitize would remove.
+	// Configured via meta_sanitize_mode:"error", the default being "clean".
+	SanitizeStrict bool
+}
+
+// stringCharsets are the classes accepted by meta_charset.
+var stringCharsets = map[string]*regexp.Regexp{
+	"alnum":           regexp.MustCompile(`^[A-Za-z0-9]+$`),
+	"slug":            regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`),
+	"hex":             regexp.MustCompile(`^[0-9A-Fa-f]+$`),
+	"ascii_printable": regexp.MustCompile(`^[\x20-\x7E]+$`),
 }
 
 func NewString(s string) String {
@@ -113,9 +164,39 @@ func (s *String) ParseOptions(tag reflect.StructTag) interface{} {
 		}
 	}
 
+	if pattern := tag.Get("meta_pattern"); pattern != "" {
+		opts.Pattern = regexp.MustCompile(`^(?:` + pattern + `)$`)
+	}
+
+	parseStringNormalization(opts, tag)
+	parseSanitizeOptions(opts, tag)
+
+	// values are compared to In once normalized, so In is normalized the same way
+	for i, v := range opts.In {
+		opts.In[i] = opts.normalize(v)
+	}
+
+	if charset := tag.Get("meta_charset"); charset != "" {
+		if _, ok := stringCharsets[charset]; !ok {
+			panic("invalid meta_charset " + charset)
+		}
+		opts.Charset = charset
+	}
+
 	return opts
 }
 
+// docPattern returns the regular expression enforced by opts, for DecoderField.DocPattern.
+func (opts *StringOptions) docPattern() string {
+	if opts.Pattern != nil {
+		return opts.Pattern.String()
+	}
+	if opts.Charset != "" {
+		return stringCharsets[opts.Charset].String()
+	}
+	return ""
+}
+
 func (s *String) JSONValue(path string, i interface{}, options interface{}) Errorable {
 	s.Path = path
 	if i == nil {
@@ -146,9 +227,17 @@ func (s *String) FormValue(value string, options interface{}) Errorable {
 
 	opts := options.(*StringOptions)
 
-	// strip
-	if opts.Strip {
-		value = strings.TrimSpace(value)
+	value = opts.normalize(value)
+
+	if opts.Sanitize != "" {
+		sanitized, clean := opts.sanitize(value)
+		if !clean && opts.SanitizeStrict {
+			return ErrMarkup
+		}
+		value = sanitized
+		if opts.Strip {
+			value = strings.TrimSpace(value)
+		}
 	}
 
 	runeCount := utf8.RuneCountInString(value)
@@ -193,6 +282,14 @@ func (s *String) FormValue(value string, options interface{}) Errorable {
 		}
 	}
 
+	if opts.Pattern != nil && !opts.Pattern.MatchString(value) {
+		return ErrPattern
+	}
+
+	if opts.Charset != "" && !stringCharsets[opts.Charset].MatchString(value) {
+		return ErrPattern
+	}
+
 	// in
 	if len(opts.In) > 0 {
 		found := false
@@ -220,6 +317,34 @@ func (s String) Value() (driver.Value, error) {
 	return nil, nil
 }
 
+// Scan reads SQL text columns. Numbers, booleans and timestamps are accepted in their text form.
+func (s *String) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*s = String{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case string:
+		*s = NewString(value)
+		return nil
+	case []byte:
+		*s = NewString(string(value))
+		return nil
+	case int64:
+		*s = NewString(strconv.FormatInt(value, 10))
+		return nil
+	case float64:
+		*s = NewString(strconv.FormatFloat(value, 'g', -1, 64))
+		return nil
+	case bool:
+		*s = NewString(strconv.FormatBool(value))
+		return nil
+	case time.Time:
+		*s = NewString(value.Format(time.RFC3339Nano))
+		return nil
+	}
+	return fmt.Errorf("meta: cannot scan %T into String", src)
+}
+
 func (s String) MarshalJSON() ([]byte, error) {
 	if s.Present && !s.Null {
 		return MetaJson.Marshal(s.Val)
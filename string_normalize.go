@@ -0,0 +1,88 @@
+package meta
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// String normalization
+//
+
+// parseStringNormalization reads the normalization tags of a String into opts.
+func parseStringNormalization(opts *StringOptions, tag reflect.StructTag) {
+	opts.StripControl = tag.Get("meta_strip_control") == "true"
+	opts.CollapseSpace = tag.Get("meta_collapse_space") == "true"
+	opts.Trim = tag.Get("meta_trim")
+	opts.TrimLeft = tag.Get("meta_trim_left")
+	opts.TrimRight = tag.Get("meta_trim_right")
+
+	switch form := strings.ToLower(tag.Get("meta_unicode")); form {
+	case "", "nfc", "nfkc":
+		opts.UnicodeForm = form
+	default:
+		panic("invalid meta_unicode " + form)
+	}
+
+	switch c := tag.Get("meta_case"); c {
+	case "", "lower", "upper", "title":
+		opts.Case = c
+	default:
+		panic("invalid meta_case " + c)
+	}
+}
+
+// normalize applies, in order, StripControl, UnicodeForm, CollapseSpace, Strip, the trim cutsets and
+// Case to value.
+func (opts *StringOptions) normalize(value string) string {
+	if opts.StripControl {
+		value = strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Cf, r) || (unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r') {
+				return -1
+			}
+			return r
+		}, value)
+	}
+
+	switch opts.UnicodeForm {
+	case "nfc":
+		value = norm.NFC.String(value)
+	case "nfkc":
+		value = norm.NFKC.String(value)
+	}
+
+	if opts.CollapseSpace {
+		value = strings.Join(strings.FieldsFunc(value, unicode.IsSpace), " ")
+	}
+
+	if opts.Strip {
+		value = strings.TrimSpace(value)
+	}
+
+	if opts.Trim != "" {
+		value = strings.Trim(value, opts.Trim)
+	}
+	if opts.TrimLeft != "" {
+		value = strings.TrimLeft(value, opts.TrimLeft)
+	}
+	if opts.TrimRight != "" {
+		value = strings.TrimRight(value, opts.TrimRight)
+	}
+
+	switch opts.Case {
+	case "lower":
+		value = strings.ToLower(value)
+	case "upper":
+		value = strings.ToUpper(value)
+	case "title":
+		// a Caser keeps state, so it can't be shared between goroutines
+		value = cases.Title(language.Und).String(value)
+	}
+
+	return value
+}
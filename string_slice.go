@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"database/sql/driver"
 	"reflect"
 	"strings"
 	"unicode/utf8"
@@ -11,6 +12,8 @@ type StringSlice struct {
 	Nullity
 	Val  []string
 	Path string
+	// sqlFormat is how Value stores the slice, "" for SQLFormatArray
+	sqlFormat string
 }
 
 type StringSliceOptions struct {
@@ -42,6 +45,7 @@ func (n *StringSlice) JSONValue(path string, i interface{}, options interface{})
 
 	n.Path = path
 	n.Val = nil
+	n.sqlFormat = sliceOpts.SQLFormat
 	n.Present = true
 	n.Null = false
 
@@ -60,6 +64,8 @@ func (n *StringSlice) JSONValue(path string, i interface{}, options interface{})
 	switch value := i.(type) {
 	case string:
 		return n.FormValue(value, options)
+	case []string:
+		return n.formValues(value, options)
 	case []interface{}:
 		n.Val = []string{}
 
@@ -99,6 +105,11 @@ func (n *StringSlice) JSONValue(path string, i interface{}, options interface{})
 			return errorsInSlice
 		}
 
+		var err Errorable
+		if n.Val, err = constrainValues(n.Val, sliceOpts); err != nil {
+			return err
+		}
+
 		if sliceOpts.MinLengthPresent && len(n.Val) < sliceOpts.MinLength {
 			return ErrMinLength
 		}
@@ -122,10 +133,11 @@ func (n *StringSlice) JSONValue(path string, i interface{}, options interface{})
 }
 
 func (i *StringSlice) FormValue(value string, options interface{}) Errorable {
-	if !utf8.ValidString(value) {
-		return ErrUtf8
-	}
+	return i.formValues([]string{value}, options)
+}
 
+// formValues decodes the values of a form key, repeated keys giving several values.
+func (i *StringSlice) formValues(values []string, options interface{}) Errorable {
 	i.Val = []string{}
 	i.Present = true
 	i.Null = false
@@ -133,12 +145,21 @@ func (i *StringSlice) FormValue(value string, options interface{}) Errorable {
 	opts := options.(*StringSliceOptions)
 	stringOpts := opts.StringOptions
 	sliceOpts := opts.SliceOptions
+	i.sqlFormat = sliceOpts.SQLFormat
 
-	if sliceOpts.Strip {
-		value = strings.TrimSpace(value)
+	values = append([]string(nil), values...)
+	blank := true
+	for j, value := range values {
+		if !utf8.ValidString(value) {
+			return ErrUtf8
+		}
+		if sliceOpts.Strip {
+			values[j] = strings.TrimSpace(value)
+		}
+		blank = blank && values[j] == ""
 	}
 
-	if value == "" {
+	if blank {
 		if sliceOpts.DiscardBlank {
 			i.Present = false
 			return nil
@@ -149,7 +170,10 @@ func (i *StringSlice) FormValue(value string, options interface{}) Errorable {
 	}
 
 	var tempS String
-	strs := strings.Split(value, ",")
+	strs, err := sliceOpts.split(values)
+	if err != nil {
+		return err
+	}
 
 	if sliceOpts.MinLengthPresent && len(strs) < sliceOpts.MinLength {
 		return ErrMinLength
@@ -181,6 +205,10 @@ func (i *StringSlice) FormValue(value string, options interface{}) Errorable {
 		return errorsInSlice
 	}
 
+	if i.Val, err = constrainValues(i.Val, sliceOpts); err != nil {
+		return err
+	}
+
 	if sliceOpts.MinLengthPresent && len(i.Val) < sliceOpts.MinLength {
 		return ErrMinLength
 	}
@@ -202,6 +230,41 @@ func (i *StringSlice) FormValue(value string, options interface{}) Errorable {
 	return nil
 }
 
+// Value stores the slice as a Postgres array literal, eg `{a,"b c"}`, or as a JSON array when the
+// field's meta_sql_format is "json".
+func (s StringSlice) Value() (driver.Value, error) {
+	if s.Null || (!s.Present && s.Val == nil) {
+		return nil, nil
+	}
+	values := make([]driver.Value, len(s.Val))
+	for i, v := range s.Val {
+		values[i] = v
+	}
+	return encodeSQLSlice(values, s.sqlFormat)
+}
+
+// WithSQLFormat returns a copy of s that Value stores in format, SQLFormatArray or SQLFormatJSON.
+func (s StringSlice) WithSQLFormat(format string) StringSlice {
+	s.sqlFormat = parseSQLFormat(format)
+	return s
+}
+
+// Scan reads Postgres text arrays, eg `{a,"b c"}`, and JSON arrays of strings. The SQL format of s
+// is kept.
+func (s *StringSlice) Scan(src interface{}) error {
+	if src == nil {
+		*s = StringSlice{Nullity: Nullity{true}, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+		return nil
+	}
+
+	elems, err := scanSliceStrings(src)
+	if err != nil {
+		return err
+	}
+	*s = StringSlice{Val: elems, Presence: Presence{true}, sqlFormat: s.sqlFormat}
+	return nil
+}
+
 func (s StringSlice) MarshalJSON() ([]byte, error) {
 	if len(s.Val) > 0 {
 		return MetaJson.Marshal(s.Val)
@@ -176,3 +176,97 @@ func TestStringSliceLength(t *testing.T) {
 	assertEqual(t, len(inputs.A.Val), 0)
 
 }
+
+func TestStringSliceScan(t *testing.T) {
+	var s StringSlice
+
+	err := s.Scan([]byte(`{a,"b c","d,e","f\"g",h\\i, NULLish }`))
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []string{"a", "b c", "d,e", `f"g`, `h\i`, "NULLish"})
+	assertEqual(t, s.Present, true)
+	assertEqual(t, s.Null, false)
+
+	err = s.Scan(`{"NULL",""}`)
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []string{"NULL", ""})
+
+	err = s.Scan("[1:2]={x,y}")
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []string{"x", "y"})
+
+	err = s.Scan("{}")
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, []string{})
+	assertEqual(t, s.Present, true)
+
+	err = s.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, s, StringSlice{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	for _, bad := range []string{"a,b", "{a,NULL}", `{"a}`, "{{a},{b}}", "{a,}", "{a b c"} {
+		err = s.Scan(bad)
+		assert(t, err != nil, bad)
+	}
+}
+
+func TestStringSliceSeparator(t *testing.T) {
+	var inputs struct {
+		Pipe   StringSlice `meta_separator:"|"`
+		Semi   StringSlice `meta_separator:";"`
+		Spaces StringSlice `meta_separator:"whitespace"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"pipe": {"a,b|c"}, "semi": {"x; y"}, "spaces": {" one  two\tthree "}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Pipe.Val, []string{"a,b", "c"})
+	assertEqual(t, inputs.Semi.Val, []string{"x", "y"})
+	assertEqual(t, inputs.Spaces.Val, []string{"one", "two", "three"})
+}
+
+func TestStringSliceQuoted(t *testing.T) {
+	var inputs struct {
+		A StringSlice `meta_quoted:"true"`
+		B StringSlice `meta_quoted:"true" meta_separator:" "`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {`plain, "with, comma" ,"say ""hi""",`}, "b": {`"new york" boston`}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []string{"plain", "with, comma", `say "hi"`})
+	assertEqual(t, inputs.B.Val, []string{"new york", "boston"})
+
+	for _, bad := range []string{`"open`, `"a"b,c`, `a,"b" c`} {
+		e = d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrQuote}, bad)
+	}
+}
+
+func TestStringSliceRepeatedKeys(t *testing.T) {
+	var inputs withStringSlice
+
+	e := withStringSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"x", "y,z"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []string{"x", "y", "z"})
+
+	e = withStringSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"", " "}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, false)
+}
+
+func TestStringSliceUniqueAndSorted(t *testing.T) {
+	var inputs struct {
+		A StringSlice `meta_unique:"case_insensitive"`
+		B StringSlice `meta_sorted:"asc"`
+		C StringSlice `meta_unique:"true" meta_sorted:"asc" meta_normalize:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"go,Rust,GO"}, "b": {"b,a"}, "c": {"b,a,b,c"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{nil, nil, ErrUnique}, "b": ErrSorted})
+	assertEqual(t, inputs.C.Val, []string{"a", "b", "c"})
+
+	e = d.DecodeJSON(&inputs, []byte(`{"a":["go","rust"],"b":["a","b"],"c":["z","y","z"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.C.Val, []string{"y", "z"})
+}
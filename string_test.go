@@ -571,3 +571,129 @@ func TestOptionalNullBlankStringOmitted(t *testing.T) {
 	assertEqual(t, inputs.A.Null, false)
 	assertEqual(t, inputs.A.Val, "")
 }
+
+func TestStringScan(t *testing.T) {
+	var s String
+
+	err := s.Scan("hello")
+	assertEqual(t, err, nil)
+	assertEqual(t, s, NewString("hello"))
+
+	err = s.Scan([]byte("bytes"))
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, "bytes")
+
+	err = s.Scan(int64(12))
+	assertEqual(t, err, nil)
+	assertEqual(t, s.Val, "12")
+
+	err = s.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, s, String{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = s.Scan(struct{}{})
+	assert(t, err != nil)
+}
+
+func TestStringPattern(t *testing.T) {
+	var inputs struct {
+		Code  String      `meta_pattern:"[A-Z]{2}-\\d{4}"`
+		Slug  String      `meta_charset:"slug"`
+		Hex   String      `meta_charset:"hex" meta_pattern:".{6}"`
+		Alnum String      `meta_charset:"alnum"`
+		Text  String      `meta_charset:"ascii_printable" doc_pattern:"printable ASCII"`
+		Tags  StringSlice `meta_charset:"slug"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{
+		"code":  {"AB-1234"},
+		"slug":  {"hello-world-2"},
+		"hex":   {"00ffAA"},
+		"alnum": {"abc123"},
+		"text":  {"Hello, world!"},
+		"tags":  {"go,web-dev"},
+	})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Code.Val, "AB-1234")
+
+	e = d.DecodeValues(&inputs, url.Values{
+		"code":  {"xAB-1234"},
+		"slug":  {"Hello-World"},
+		"hex":   {"00ffAG"},
+		"alnum": {"abc_123"},
+		"text":  {"tab\there"},
+		"tags":  {"go,web--dev"},
+	})
+	assertEqual(t, e, ErrorHash{
+		"code":  ErrPattern,
+		"slug":  ErrPattern,
+		"hex":   ErrPattern,
+		"alnum": ErrPattern,
+		"text":  ErrPattern,
+		"tags":  ErrorSlice{nil, ErrPattern},
+	})
+
+	e = d.DecodeValues(&inputs, url.Values{"hex": {"00ff"}})
+	assertEqual(t, e, ErrorHash{"hex": ErrPattern})
+
+	patterns := map[string]string{}
+	for _, f := range d.Fields {
+		patterns[f.Name] = f.DocPattern
+	}
+	assertEqual(t, patterns, map[string]string{
+		"code":  `^(?:[A-Z]{2}-\d{4})$`,
+		"slug":  `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+		"hex":   `^(?:.{6})$`,
+		"alnum": `^[A-Za-z0-9]+$`,
+		"text":  "printable ASCII",
+		"tags":  `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+	})
+}
+
+func TestStringNormalization(t *testing.T) {
+	var inputs struct {
+		Username String `meta_unicode:"nfkc" meta_strip_control:"true" meta_case:"lower" meta_max_runes:"5"`
+		Name     String `meta_collapse_space:"true" meta_case:"title"`
+		Email    String `meta_strip_control:"true" meta_case:"lower" meta_in:"a@example.com"`
+		Path     String `meta_trim:"/" meta_trim_right:"."`
+		Code     String `meta_unicode:"nfc" meta_case:"upper" meta_trim_left:"#"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{
+		"username": {"Ａｄｍｉｎ\u200b"},
+		"name":     {"  mary \t\n  ann  smith "},
+		"email":    {" A@Example.com\u0000"},
+		"path":     {" //docs/api.. "},
+		"code":     {"#éte"},
+	})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Username.Val, "admin")
+	assertEqual(t, inputs.Name.Val, "Mary Ann Smith")
+	assertEqual(t, inputs.Email.Val, "a@example.com")
+	assertEqual(t, inputs.Path.Val, "docs/api")
+	assertEqual(t, inputs.Code.Val, "ÉTE")
+
+	var blank struct {
+		Username String `meta_strip_control:"true" meta_required:"true"`
+		Name     String `meta_collapse_space:"true" meta_required:"true"`
+	}
+	e = NewDecoder(&blank).DecodeValues(&blank, url.Values{"username": {"\u200b\ufeff"}, "name": {"\u00a0\t "}})
+	assertEqual(t, e, ErrorHash{"username": ErrBlank, "name": ErrBlank})
+
+	e = d.DecodeValues(&inputs, url.Values{"username": {"ａｄｍｉｎｓ"}})
+	assertEqual(t, e, ErrorHash{"username": ErrMaxRunes})
+
+	// bidi overrides and isolates, and other format characters
+	e = d.DecodeValues(&inputs, url.Values{"username": {"\u202eadm\u2066i\u2069n\u202c\u00ad\u2062"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Username.Val, "admin")
+
+	var in struct {
+		Role String `meta_case:"lower" meta_in:"Admin, Editor"`
+	}
+	e = NewDecoder(&in).DecodeValues(&in, url.Values{"role": {"EDITOR"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, in.Role.Val, "editor")
+}
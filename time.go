@@ -3,6 +3,8 @@ package meta
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -29,9 +31,19 @@ type TimeOptions struct {
 	// Configured via meta_format tag.
 	// Can be predefined formats (RFC3339, DateOnly, etc.) or custom Go time layouts.
 	// Multiple formats can be specified as comma-separated values.
+	// The epoch formats "unix", "unix_ms" and "unix_ns" accept JSON numbers and numeric strings as seconds,
+	// milliseconds or nanoseconds since the Unix epoch.
 	// Default: [RFC3339, "expression"] (supports RFC3339 format and time expressions like "now", "3_days_ago")
-	// Examples: `meta_format:"RFC3339"`, `meta_format:"DateOnly"`, `meta_format:"1/2/2006"`, `meta_format:"RFC3339,2006-01-02 15:04:05"`
+	// Examples: `meta_format:"RFC3339"`, `meta_format:"DateOnly"`, `meta_format:"1/2/2006"`, `meta_format:"RFC3339,2006-01-02 15:04:05"`,
+	// `meta_format:"unix_ms,RFC3339"`
 	Format []string
+	// OutputFormat is the layout FormattedTime, TimeRange and TimeSlice fields render decoded values in.
+	// It accepts the same predefined names, layouts and epoch formats as Format. Time fields always
+	// render RFC 3339.
+	// Configured via meta_output_format tag.
+	// Default: "" (RFC 3339 with nanoseconds)
+	// Examples: `meta_output_format:"DateOnly"`, `meta_output_format:"unix_ms"`
+	OutputFormat string
 	// MinDate sets the minimum allowed date/time value.
 	// Configured via meta_min tag.
 	// Can be an absolute date/time string or a relative expression.
@@ -56,6 +68,30 @@ type TimeOptions struct {
 	// Direction defaults to "down" if not specified (e.g., `meta_round:"day"` is equivalent to `meta_round:"day:down"`)
 	// Examples: `meta_round:"day:down"`, `meta_round:"hour:up"`, `meta_round:"monday:nearest"`, `meta_round:"week:down"`
 	Round *roundConfig
+	// Location is the time zone used to parse layouts without a zone, to evaluate expressions
+	// like "today" or "3_days_ago", and to apply rounding.
+	// Configured via meta_location tag or DecoderOptions.Location, the tag taking precedence.
+	// Default: nil (layouts without a zone are parsed as UTC, expressions use the server's local zone)
+	// Examples: `meta_location:"America/New_York"`, `meta_location:"UTC"`
+	Location *time.Location
+	// LocationField names another input field holding an IANA zone name, eg a "time_zone" string.
+	// When that field names a valid zone it replaces Location for the current input, and when it is
+	// missing or blank Location is used. Any other value fails with ErrLocation.
+	// Configured via meta_location_field tag.
+	// NOTE: absolute meta_min/meta_max limits are resolved once, in Location
+	// Example: `meta_location_field:"time_zone"`
+	LocationField string
+	// UTC converts parsed values to UTC after rounding.
+	// Configured via meta_utc tag or DecoderOptions.UTC.
+	// Default: false (values keep the zone they were parsed or evaluated in)
+	UTC bool
+	// Clock provides the current time for expressions and relative limits.
+	// Configured via DecoderOptions.Clock.
+	// Default: nil (time.Now)
+	Clock Clock
+
+	// invalidLocation is set by withSource when the LocationField input isn't a zone
+	invalidLocation bool
 }
 
 type roundConfig struct {
@@ -76,7 +112,7 @@ type timeComponent struct {
 
 type expressionParser struct {
 	*regexp.Regexp
-	Parse func([]string) (time.Time, bool)
+	Parse func(matches []string, now time.Time) (time.Time, bool)
 }
 
 // unitRoundingMethods defines how to round standard units
@@ -89,6 +125,13 @@ type unitRoundingMethods struct {
 // Constants
 //
 
+const (
+	// Epoch formats for TimeOptions.Format
+	FormatUnix   = "unix"
+	FormatUnixMs = "unix_ms"
+	FormatUnixNs = "unix_ns"
+)
+
 const (
 	// TimeComparisonTolerance allows for some tolerance in time comparisons
 	// to handle time expression resolution differences
@@ -147,6 +190,13 @@ var timeFormatMap = map[string]string{
 	"TimeOnly":    time.TimeOnly,
 }
 
+// epochUnits maps epoch formats to the unit they count
+var epochUnits = map[string]time.Duration{
+	FormatUnix:   time.Second,
+	FormatUnixMs: time.Millisecond,
+	FormatUnixNs: time.Nanosecond,
+}
+
 // dayNameToWeekday maps day names to time.Weekday values
 var dayNameToWeekday = map[string]time.Weekday{
 	"sunday":    time.Sunday,
@@ -203,20 +253,17 @@ var timeUnitAddDate = map[RoundingUnit]func(int) (int, int, int){
 	UnitDay:   func(delta int) (int, int, int) { return 0, 0, delta },
 }
 
-// absoluteTimeExpressions maps expression names to their time values
-var absoluteTimeExpressions = map[string]func() time.Time{
-	"now": func() time.Time { return time.Now() },
-	"today": func() time.Time {
-		now := time.Now()
+// absoluteTimeExpressions maps expression names to their time values, relative to now and in now's location
+var absoluteTimeExpressions = map[string]func(now time.Time) time.Time{
+	"now": func(now time.Time) time.Time { return now },
+	"today": func(now time.Time) time.Time {
 		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	},
-	"yesterday": func() time.Time {
-		now := time.Now()
+	"yesterday": func(now time.Time) time.Time {
 		base := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		return base.AddDate(0, 0, -1)
 	},
-	"tomorrow": func() time.Time {
-		now := time.Now()
+	"tomorrow": func(now time.Time) time.Time {
 		base := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		return base.AddDate(0, 0, 1)
 	},
@@ -309,8 +356,26 @@ var boundaryChecks = map[string]func(time.Time) bool{
 var (
 	relativeTimeRegex = regexp.MustCompile(`^(\d+)_(year|month|week|day|hour|minute|second|nanosecond)s?_(ago|from_now)$`)
 	absoluteTimeRegex = regexp.MustCompile(`^(now|today|yesterday|tomorrow)$`)
+	anchorTimeRegex   = regexp.MustCompile(`^(start|end)_of_(year|month|week|day|hour|minute)$`)
+	weekdayTimeRegex  = regexp.MustCompile(`^(last|next)_(sunday|monday|tuesday|wednesday|thursday|friday|saturday|sun|mon|tue|wed|thu|fri|sat)$`)
+	unixTimeRegex     = regexp.MustCompile(`^@(-?\d+)(?:\.(\d{1,9}))?$`)
+	atTimeRegex       = regexp.MustCompile(`^(.+)_at_(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+	offsetTimeRegex   = regexp.MustCompile(`^(.+?)((?:[+-](?:\d+(?:mo|[ywdhms]))+)+)$`)
+	offsetTermRegex   = regexp.MustCompile(`([+-]?)(\d+)(mo|[ywdhms])`)
+	epochRegex        = regexp.MustCompile(`^(-?\d+)(?:\.(\d{1,9}))?$`)
 )
 
+// offsetUnits maps the units of offset expressions like "now-3h" or "today+1mo" to a function applying them
+var offsetUnits = map[string]func(time.Time, int) time.Time{
+	"y":  func(t time.Time, n int) time.Time { return t.AddDate(n, 0, 0) },
+	"mo": func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) },
+	"w":  func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+	"d":  func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) },
+	"h":  func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Hour) },
+	"m":  func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Minute) },
+	"s":  func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Second) },
+}
+
 var timeExpressionParsers = []expressionParser{
 	{
 		Regexp: relativeTimeRegex,
@@ -320,6 +385,33 @@ var timeExpressionParsers = []expressionParser{
 		Regexp: absoluteTimeRegex,
 		Parse:  parseAbsoluteTimeExpression,
 	},
+	{
+		Regexp: anchorTimeRegex,
+		Parse:  parseAnchorTimeExpression,
+	},
+	{
+		Regexp: weekdayTimeRegex,
+		Parse:  parseWeekdayTimeExpression,
+	},
+	{
+		Regexp: unixTimeRegex,
+		Parse:  parseUnixTimeExpression,
+	},
+}
+
+// Compound expressions resolve their base expression recursively, so they are registered
+// in init to avoid an initialization cycle through resolveTimeExpression.
+func init() {
+	timeExpressionParsers = append(timeExpressionParsers,
+		expressionParser{
+			Regexp: atTimeRegex,
+			Parse:  parseAtTimeExpression,
+		},
+		expressionParser{
+			Regexp: offsetTimeRegex,
+			Parse:  parseOffsetTimeExpression,
+		},
+	)
 }
 
 //
@@ -340,7 +432,18 @@ func (t *Time) ParseOptions(tag reflect.StructTag) interface{} {
 		DiscardBlank: tag.Get("meta_discard_blank") != "false",
 		Null:         tag.Get("meta_null") == "true",
 		Format:       parseFormats(tag.Get("meta_format")),
+		OutputFormat: lookupTimeFormat(tag.Get("meta_output_format")),
+		UTC:          tag.Get("meta_utc") == "true",
+	}
+
+	if name := tag.Get("meta_location"); name != "" {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			panic(err.Error())
+		}
+		opts.Location = loc
 	}
+	opts.LocationField = tag.Get("meta_location_field")
 
 	opts.MinDate = newDateLimit(tag.Get("meta_min"), opts)
 	opts.MaxDate = newDateLimit(tag.Get("meta_max"), opts)
@@ -359,15 +462,23 @@ func (t *Time) JSONValue(path string, i interface{}, options interface{}) Errora
 		return t.FormValue("", options)
 	}
 
+	opts := options.(*TimeOptions)
+	if opts.invalidLocation {
+		return ErrLocation
+	}
+
 	switch value := i.(type) {
 	case time.Time:
-		opts := options.(*TimeOptions)
 		if value.IsZero() {
 			return t.handleZeroTimeValue(opts)
 		}
 		return t.handleNonZeroTimeValue(value, opts)
 	case string:
 		return t.FormValue(value, options)
+	case json.Number:
+		return t.parseEpochValue(value.String(), opts)
+	case float64:
+		return t.parseEpochValue(strconv.FormatFloat(value, 'f', -1, 64), opts)
 	}
 
 	return ErrTime
@@ -379,6 +490,9 @@ func (t *Time) FormValue(value string, options interface{}) Errorable {
 	if value == "" {
 		return t.handleEmptyValue(opts)
 	}
+	if opts.invalidLocation {
+		return ErrLocation
+	}
 
 	return t.parseTimeValue(value, opts)
 }
@@ -390,6 +504,42 @@ func (t Time) Value() (driver.Value, error) {
 	return nil, nil
 }
 
+// Scan reads SQL timestamps, returned by drivers either as time.Time or as text in RFC 3339 or
+// the Postgres output format, eg "2024-03-05 14:30:00.5+00".
+func (t *Time) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*t = Time{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case time.Time:
+		*t = Time{Val: value, Presence: Presence{true}}
+		return nil
+	case string:
+		return t.scanString(value)
+	case []byte:
+		return t.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into Time", src)
+}
+
+// sqlTimeLayouts are the text forms of SQL timestamps accepted by Time.Scan.
+var sqlTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func (t *Time) scanString(value string) error {
+	for _, layout := range sqlTimeLayouts {
+		if v, err := time.Parse(layout, value); err == nil {
+			*t = Time{Val: v, Presence: Presence{true}}
+			return nil
+		}
+	}
+	return fmt.Errorf("meta: cannot scan %q into Time", value)
+}
+
 func (t Time) MarshalJSON() ([]byte, error) {
 	if t.Present && !t.Null {
 		return MetaJson.Marshal(t.Val)
@@ -397,6 +547,15 @@ func (t Time) MarshalJSON() ([]byte, error) {
 	return nullString, nil
 }
 
+// MarshalText renders the value in RFC 3339 with nanoseconds for form and query string encoders.
+// Absent and null values render as "".
+func (t Time) MarshalText() ([]byte, error) {
+	if !t.Present || t.Null {
+		return []byte{}, nil
+	}
+	return t.Val.MarshalText()
+}
+
 func (t *Time) UnmarshalJSON(b []byte) error {
 	if bytes.Equal(nullString, b) {
 		t.Nullity = Nullity{true}
@@ -411,6 +570,57 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// marshalTimeJSON renders v in format: a string for layouts, a whole number of units for epoch
+// formats, and an RFC 3339 string for "".
+func marshalTimeJSON(v time.Time, format string) ([]byte, error) {
+	if unit, ok := epochUnits[format]; ok {
+		return []byte(strconv.FormatInt(epochOf(v, unit), 10)), nil
+	}
+	if format != "" {
+		return MetaJson.Marshal(v.Format(format))
+	}
+	return MetaJson.Marshal(v)
+}
+
+// marshalTimeText renders v in format like marshalTimeJSON, without quoting strings.
+func marshalTimeText(v time.Time, format string) ([]byte, error) {
+	if unit, ok := epochUnits[format]; ok {
+		return []byte(strconv.FormatInt(epochOf(v, unit), 10)), nil
+	}
+	if format != "" {
+		return []byte(v.Format(format)), nil
+	}
+	return v.MarshalText()
+}
+
+// unmarshalTimeJSON reads a non-null JSON value rendered by marshalTimeJSON in format. RFC 3339
+// strings are accepted in every format.
+func unmarshalTimeJSON(b []byte, format string) (time.Time, error) {
+	if len(b) > 0 && b[0] != '"' {
+		unit, ok := epochUnits[format]
+		if !ok {
+			return time.Time{}, fmt.Errorf("meta: cannot unmarshal %s into Time without an epoch format", b)
+		}
+		v, ok := parseEpoch(string(b), unit)
+		if !ok {
+			return time.Time{}, fmt.Errorf("meta: invalid epoch time %s", b)
+		}
+		return v, nil
+	}
+	if _, ok := epochUnits[format]; !ok && format != "" {
+		var s string
+		if err := MetaJson.Unmarshal(b, &s); err != nil {
+			return time.Time{}, err
+		}
+		if v, err := time.Parse(format, s); err == nil {
+			return v, nil
+		}
+	}
+	var v time.Time
+	err := MetaJson.Unmarshal(b, &v)
+	return v, err
+}
+
 //
 // Value Handling Methods - Consolidated
 //
@@ -422,7 +632,7 @@ func (t *Time) handleZeroTimeValue(opts *TimeOptions) Errorable {
 func (t *Time) handleNonZeroTimeValue(value time.Time, opts *TimeOptions) Errorable {
 	t.Present = true
 	t.Val = value
-	t.applyRounding(opts)
+	t.normalize(opts)
 	return t.assertTimeRange(opts)
 }
 
@@ -453,6 +663,10 @@ func (t *Time) parseTimeValue(value string, opts *TimeOptions) Errorable {
 			if t.parseTimeExpression(value, opts) {
 				return t.assertTimeRange(opts)
 			}
+		case FormatUnix, FormatUnixMs, FormatUnixNs:
+			if t.parseTimeEpoch(value, epochUnits[format], opts) {
+				return t.assertTimeRange(opts)
+			}
 		default:
 			if t.parseTimeFormat(value, format, opts) {
 				return t.assertTimeRange(opts)
@@ -463,20 +677,51 @@ func (t *Time) parseTimeValue(value string, opts *TimeOptions) Errorable {
 }
 
 func (t *Time) parseTimeExpression(value string, opts *TimeOptions) bool {
-	if v := resolveTimeExpression(value); v != nil {
+	if v := resolveTimeExpression(value, opts.now()); v != nil {
 		t.Val = *v
 		t.Present = true
-		t.applyRounding(opts)
+		t.normalize(opts)
 		return true
 	}
 	return false
 }
 
+func (t *Time) parseTimeEpoch(value string, unit time.Duration, opts *TimeOptions) bool {
+	v, ok := parseEpoch(value, unit)
+	if !ok {
+		return false
+	}
+	if opts.Location != nil {
+		v = v.In(opts.Location)
+	}
+	t.Val = v
+	t.Present = true
+	t.normalize(opts)
+	return true
+}
+
+// parseEpochValue handles JSON numbers, which are only accepted by the epoch formats.
+func (t *Time) parseEpochValue(value string, opts *TimeOptions) Errorable {
+	for _, format := range opts.Format {
+		if unit, ok := epochUnits[format]; ok && t.parseTimeEpoch(value, unit, opts) {
+			return t.assertTimeRange(opts)
+		}
+	}
+	return ErrTime
+}
+
 func (t *Time) parseTimeFormat(value string, format string, opts *TimeOptions) bool {
-	if v, err := time.Parse(format, value); err == nil {
+	var v time.Time
+	var err error
+	if opts.Location != nil {
+		v, err = time.ParseInLocation(format, value, opts.Location)
+	} else {
+		v, err = time.Parse(format, value)
+	}
+	if err == nil {
 		t.Val = v
 		t.Present = true
-		t.applyRounding(opts)
+		t.normalize(opts)
 		return true
 	}
 	return false
@@ -495,35 +740,75 @@ func parseFormats(formatTag string) []string {
 	result := make([]string, 0, len(formats))
 
 	for _, format := range formats {
-		format = strings.TrimSpace(format)
-		if predefined, exists := timeFormatMap[format]; exists {
-			result = append(result, predefined)
-		} else {
-			result = append(result, format)
-		}
+		result = append(result, lookupTimeFormat(format))
 	}
 
 	return result
 }
 
+// lookupTimeFormat resolves a predefined format name like "RFC3339" to its layout.
+func lookupTimeFormat(format string) string {
+	format = strings.TrimSpace(format)
+	if predefined, exists := timeFormatMap[format]; exists {
+		return predefined
+	}
+	return format
+}
+
+//
+// Epoch Parsing
+//
+
+// parseEpoch reads a whole or decimal number of units since the Unix epoch, eg "1700000000" or
+// "1700000000.25" for seconds. The result is in UTC.
+func parseEpoch(value string, unit time.Duration) (time.Time, bool) {
+	m := epochRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return time.Time{}, false
+	}
+
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	perSecond := int64(time.Second / unit)
+	nsec := (n % perSecond) * int64(unit)
+	if m[2] != "" {
+		fraction, _ := strconv.ParseInt((m[2] + "00000000")[:9], 10, 64)
+		fraction = fraction * int64(unit) / int64(time.Second)
+		if strings.HasPrefix(m[1], "-") {
+			fraction = -fraction
+		}
+		nsec += fraction
+	}
+	return time.Unix(n/perSecond, nsec).UTC(), true
+}
+
+// epochOf returns the whole number of units between the Unix epoch and t, rounding towards the past.
+func epochOf(t time.Time, unit time.Duration) int64 {
+	perSecond := int64(time.Second / unit)
+	return t.Unix()*perSecond + int64(t.Nanosecond())/int64(unit)
+}
+
 //
 // Expression Parsing
 //
 
-func resolveTimeExpression(value string) *time.Time {
+func resolveTimeExpression(value string, now time.Time) *time.Time {
 	for _, parser := range timeExpressionParsers {
 		submatches := parser.Regexp.FindStringSubmatch(value)
 		if len(submatches) == 0 {
 			continue
 		}
-		if v, ok := parser.Parse(submatches); ok {
+		if v, ok := parser.Parse(submatches, now); ok {
 			return &v
 		}
 	}
 	return nil
 }
 
-func parseRelativeTimeExpression(matches []string) (time.Time, bool) {
+func parseRelativeTimeExpression(matches []string, now time.Time) (time.Time, bool) {
 	delta, err := strconv.Atoi(matches[1])
 	if err != nil {
 		return time.Time{}, false
@@ -538,16 +823,16 @@ func parseRelativeTimeExpression(matches []string) (time.Time, bool) {
 	if addDateFunc, exists := timeUnitAddDate[unit]; exists {
 		years, months, days := addDateFunc(delta)
 		if isAgo {
-			result = time.Now().AddDate(-years, -months, -days)
+			result = now.AddDate(-years, -months, -days)
 		} else {
-			result = time.Now().AddDate(years, months, days)
+			result = now.AddDate(years, months, days)
 		}
 	} else if duration, exists := timeUnitDuration[unit]; exists {
 		// Handle Duration units (hour, minute, second, nanosecond)
 		if isAgo {
-			result = time.Now().Add(-time.Duration(delta) * duration)
+			result = now.Add(-time.Duration(delta) * duration)
 		} else {
-			result = time.Now().Add(time.Duration(delta) * duration)
+			result = now.Add(time.Duration(delta) * duration)
 		}
 	} else {
 		return time.Time{}, false
@@ -556,13 +841,112 @@ func parseRelativeTimeExpression(matches []string) (time.Time, bool) {
 	return result, true
 }
 
-func parseAbsoluteTimeExpression(matches []string) (time.Time, bool) {
+func parseAbsoluteTimeExpression(matches []string, now time.Time) (time.Time, bool) {
 	if fn, exists := absoluteTimeExpressions[matches[1]]; exists {
-		return fn(), true
+		return fn(now), true
 	}
 	return time.Time{}, false
 }
 
+// parseAnchorTimeExpression handles "start_of_<unit>" and "end_of_<unit>". Weeks start on Monday,
+// and the end of a unit is the last nanosecond before the start of the next one.
+func parseAnchorTimeExpression(matches []string, now time.Time) (time.Time, bool) {
+	unit := RoundingUnit(matches[2])
+	start := (&Time{Val: now}).roundDown(unit)
+	if matches[1] == "start" {
+		return start, true
+	}
+
+	var next time.Time
+	if addDateFunc, exists := timeUnitAddDate[unit]; exists {
+		next = start.AddDate(addDateFunc(1))
+	} else {
+		next = start.Add(timeUnitDuration[unit])
+	}
+	return next.Add(-time.Nanosecond), true
+}
+
+// parseWeekdayTimeExpression handles "last_<day>" and "next_<day>", the start of the closest such day
+// strictly before or after today.
+func parseWeekdayTimeExpression(matches []string, now time.Time) (time.Time, bool) {
+	weekday, ok := dayNameToWeekday[matches[2]]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	today := absoluteTimeExpressions["today"](now)
+	var days int
+	if matches[1] == "last" {
+		days = -((int(today.Weekday()-weekday)+6)%7 + 1)
+	} else {
+		days = (int(weekday-today.Weekday())+6)%7 + 1
+	}
+	return today.AddDate(0, 0, days), true
+}
+
+// parseUnixTimeExpression handles "@<seconds>[.<fraction>]" Unix timestamps.
+func parseUnixTimeExpression(matches []string, now time.Time) (time.Time, bool) {
+	sec, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var nsec int64
+	if matches[2] != "" {
+		nsec, _ = strconv.ParseInt((matches[2] + "00000000")[:9], 10, 64)
+		if strings.HasPrefix(matches[1], "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec).In(now.Location()), true
+}
+
+// parseAtTimeExpression handles "<expression>_at_HH:MM[:SS]", eg "2_days_ago_at_09:00" or "next_friday_at_17:30".
+func parseAtTimeExpression(matches []string, now time.Time) (time.Time, bool) {
+	base := resolveTimeExpression(matches[1], now)
+	if base == nil {
+		return time.Time{}, false
+	}
+
+	hour, _ := strconv.Atoi(matches[2])
+	minute, _ := strconv.Atoi(matches[3])
+	second := 0
+	if matches[4] != "" {
+		second, _ = strconv.Atoi(matches[4])
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	return time.Date(base.Year(), base.Month(), base.Day(), hour, minute, second, 0, base.Location()), true
+}
+
+// parseOffsetTimeExpression handles "<expression>[+-]<n><unit>...", eg "now-3h", "today+1d", "now+1h30m"
+// or "start_of_month-1mo+2w". Units are y, mo, w, d, h, m and s; unsigned terms keep the previous sign.
+func parseOffsetTimeExpression(matches []string, now time.Time) (time.Time, bool) {
+	base := resolveTimeExpression(matches[1], now)
+	if base == nil {
+		return time.Time{}, false
+	}
+
+	result := *base
+	negative := false
+	for _, term := range offsetTermRegex.FindAllStringSubmatch(matches[2], -1) {
+		n, err := strconv.Atoi(term[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if term[1] != "" {
+			negative = term[1] == "-"
+		}
+		if negative {
+			n = -n
+		}
+		result = offsetUnits[term[3]](result, n)
+	}
+	return result, true
+}
+
 //
 // Tag Parsing
 //
@@ -611,11 +995,23 @@ func normalizeDayName(dayName string) (string, bool) {
 // Rounding Methods
 //
 
+// normalize applies the configured rounding and UTC conversion to a freshly parsed value.
+func (t *Time) normalize(opts *TimeOptions) {
+	t.applyRounding(opts)
+	if opts.UTC {
+		t.Val = t.Val.UTC()
+	}
+}
+
 func (t *Time) applyRounding(opts *TimeOptions) {
 	if opts.Round == nil {
 		return
 	}
 
+	if opts.Location != nil {
+		t.Val = t.Val.In(opts.Location)
+	}
+
 	switch opts.Round.direction {
 	case DirectionDown:
 		t.Val = t.roundDown(opts.Round.unit)
@@ -788,6 +1184,44 @@ func (t *Time) isAtDayNameBoundary(unit string) bool {
 	return t.Val.Equal(down)
 }
 
+//
+// Location Methods
+//
+
+// now returns the current time from the configured clock, in the configured location or in the server's local zone.
+func (opts *TimeOptions) now() time.Time {
+	now := clockNow(opts.Clock)
+	if opts.Location != nil {
+		return now.In(opts.Location)
+	}
+	return now
+}
+
+// withSource returns a copy of opts using the zone named by the LocationField input, if any.
+func (opts *TimeOptions) withSource(src source) interface{} {
+	if opts.LocationField == "" {
+		return opts
+	}
+
+	var val interface{}
+	src.Get(opts.LocationField).Value(&val)
+	if val == nil {
+		return opts
+	}
+	name, ok := val.(string)
+	if ok && strings.TrimSpace(name) == "" {
+		return opts
+	}
+
+	resolved := *opts
+	if loc, ok := lookupLocation(name); ok {
+		resolved.Location = loc
+	} else {
+		resolved.invalidLocation = true
+	}
+	return &resolved
+}
+
 //
 // Date Limit Methods
 //
@@ -800,7 +1234,7 @@ func newDateLimit(raw string, opts *TimeOptions) *dateLimit {
 	value, exclusive := parseExclusivePrefix(raw)
 
 	// Always try to resolve expressions for min/max dates
-	if v := resolveTimeExpression(value); v != nil {
+	if v := resolveTimeExpression(value, opts.now()); v != nil {
 		return &dateLimit{raw: value, exclusive: exclusive}
 	}
 
@@ -815,7 +1249,7 @@ func newDateLimit(raw string, opts *TimeOptions) *dateLimit {
 func (d *dateLimit) Value(opts *TimeOptions) time.Time {
 	if d.isAbsolute {
 		return d.value
-	} else if v := resolveTimeExpression(d.raw); v != nil {
+	} else if v := resolveTimeExpression(d.raw, opts.now()); v != nil {
 		// Apply the same rounding to expression-based boundaries
 		if opts.Round != nil {
 			tempTime := Time{Val: *v}
@@ -825,7 +1259,7 @@ func (d *dateLimit) Value(opts *TimeOptions) time.Time {
 		return *v
 	}
 
-	return time.Now()
+	return opts.now()
 }
 
 func parseExclusivePrefix(raw string) (string, bool) {
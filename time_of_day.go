@@ -0,0 +1,348 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//
+// Core Types and Structures
+//
+
+// ClockTime is a time of day with second precision, without a date or time zone.
+type ClockTime struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+type TimeOfDay struct {
+	Val ClockTime
+	Nullity
+	Presence
+	Path string
+}
+
+type TimeOfDayOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// Format specifies the layouts used to parse time of day strings.
+	// Configured via meta_format tag, with the same predefined names as TimeOptions.Format.
+	// Default: [TimeOnly, "15:04", Kitchen, "3:04pm"]
+	// Examples: `meta_format:"TimeOnly"`, `meta_format:"15:04,Kitchen"`
+	Format []string
+	// Min and Max bound the value, written in one of Format. Supports exclusive boundaries with "!" prefix.
+	// Configured via meta_min and meta_max tags.
+	// Examples: `meta_min:"09:00" meta_max:"17:30"`, `meta_max:"!18:00"`
+	// NOTE: configured rounding is applied before the comparison
+	Min *clockTimeLimit
+	Max *clockTimeLimit
+	// Round rounds the value to a multiple of a duration since midnight.
+	// Configured via meta_round tag as "duration:direction", direction being up, down or nearest.
+	// Direction defaults to "down". Rounding past the end of the day wraps to 00:00:00.
+	// Examples: `meta_round:"1m"`, `meta_round:"15m:nearest"`, `meta_round:"30m:up"`
+	Round *clockRoundConfig
+}
+
+type clockTimeLimit struct {
+	value     ClockTime
+	exclusive bool
+}
+
+type clockRoundConfig struct {
+	granularity time.Duration
+	direction   RoundingDirection
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+var defaultTimeOfDayFormats = []string{timeFormatMap["TimeOnly"], "15:04", timeFormatMap["Kitchen"], "3:04pm"}
+
+//
+// Constructors
+//
+
+func NewClockTime(hour, minute, second int) ClockTime {
+	return clockTimeFromSeconds(hour*3600 + minute*60 + second)
+}
+
+// ClockTimeOf returns the time of day of t in t's location.
+func ClockTimeOf(t time.Time) ClockTime {
+	return ClockTime{t.Hour(), t.Minute(), t.Second()}
+}
+
+func NewTimeOfDay(c ClockTime) TimeOfDay {
+	return TimeOfDay{c, Nullity{false}, Presence{true}, ""}
+}
+
+//
+// ClockTime Methods
+//
+
+// String returns the time in time.TimeOnly format.
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", c.Hour, c.Minute, c.Second)
+}
+
+// SinceMidnight returns the duration elapsed since 00:00:00.
+func (c ClockTime) SinceMidnight() time.Duration {
+	return time.Duration(c.seconds()) * time.Second
+}
+
+// On returns the instant at this time of day on date d in loc.
+func (c ClockTime) On(d CivilDate, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, c.Hour, c.Minute, c.Second, 0, loc)
+}
+
+func (c ClockTime) Compare(other ClockTime) int {
+	return compareInts(c.seconds(), other.seconds())
+}
+
+func (c ClockTime) Before(other ClockTime) bool {
+	return c.Compare(other) < 0
+}
+
+func (c ClockTime) After(other ClockTime) bool {
+	return c.Compare(other) > 0
+}
+
+func (c ClockTime) seconds() int {
+	return c.Hour*3600 + c.Minute*60 + c.Second
+}
+
+func clockTimeFromSeconds(s int) ClockTime {
+	s %= secondsPerDay
+	if s < 0 {
+		s += secondsPerDay
+	}
+	return ClockTime{s / 3600, s % 3600 / 60, s % 60}
+}
+
+//
+// Core TimeOfDay Methods
+//
+
+func (t *TimeOfDay) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &TimeOfDayOptions{
+		Required:     tag.Get("meta_required") == "true",
+		DiscardBlank: tag.Get("meta_discard_blank") != "false",
+		Null:         tag.Get("meta_null") == "true",
+		Format:       defaultTimeOfDayFormats,
+	}
+
+	if formatTag := tag.Get("meta_format"); formatTag != "" {
+		opts.Format = parseFormats(formatTag)
+	}
+
+	if roundTag := tag.Get("meta_round"); roundTag != "" {
+		opts.Round = parseClockRoundConfig(roundTag)
+	}
+
+	opts.Min = newClockTimeLimit(tag.Get("meta_min"), opts)
+	opts.Max = newClockTimeLimit(tag.Get("meta_max"), opts)
+
+	return opts
+}
+
+func (t *TimeOfDay) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	t.Path = path
+	if i == nil {
+		return t.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return t.FormValue(value, options)
+	case ClockTime:
+		return t.validateValue(value, options.(*TimeOfDayOptions))
+	}
+
+	return ErrTimeOfDay
+}
+
+func (t *TimeOfDay) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*TimeOfDayOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			t.Present = true
+			t.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			t.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	c, ok := parseClockTime(value, opts.Format)
+	if !ok {
+		return ErrTimeOfDay
+	}
+	return t.validateValue(c, opts)
+}
+
+func (t *TimeOfDay) validateValue(value ClockTime, opts *TimeOfDayOptions) Errorable {
+	value = opts.Round.apply(value)
+
+	if opts.Min != nil {
+		cmp := value.Compare(opts.Min.value)
+		if cmp < 0 || (cmp == 0 && opts.Min.exclusive) {
+			return ErrMin
+		}
+	}
+	if opts.Max != nil {
+		cmp := value.Compare(opts.Max.value)
+		if cmp > 0 || (cmp == 0 && opts.Max.exclusive) {
+			return ErrMax
+		}
+	}
+
+	t.Val = value
+	t.Present = true
+	return nil
+}
+
+// Value stores the time as a TimeOnly string, suitable for SQL TIME columns.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if t.Present && !t.Null {
+		return t.Val.String(), nil
+	}
+	return nil, nil
+}
+
+// Scan reads SQL TIME values, returned by drivers either as time.Time or as text.
+// Fractional seconds are truncated.
+func (t *TimeOfDay) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*t = TimeOfDay{Nullity: Nullity{true}, Presence: Presence{true}}
+		return nil
+	case time.Time:
+		*t = NewTimeOfDay(ClockTimeOf(value))
+		return nil
+	case string:
+		return t.scanString(value)
+	case []byte:
+		return t.scanString(string(value))
+	}
+	return fmt.Errorf("meta: cannot scan %T into TimeOfDay", src)
+}
+
+func (t *TimeOfDay) scanString(value string) error {
+	v, err := time.Parse("15:04:05.999999999", value)
+	if err != nil {
+		return err
+	}
+	*t = NewTimeOfDay(ClockTimeOf(v))
+	return nil
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	if t.Present && !t.Null {
+		return MetaJson.Marshal(t.Val.String())
+	}
+	return nullString, nil
+}
+
+func (t *TimeOfDay) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		t.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	v, err := time.Parse(time.TimeOnly, s)
+	if err != nil {
+		return err
+	}
+
+	t.Val = ClockTimeOf(v)
+	t.Presence = Presence{true}
+	t.Nullity = Nullity{false}
+	return nil
+}
+
+//
+// Parsing
+//
+
+func parseClockTime(value string, formats []string) (ClockTime, bool) {
+	for _, format := range formats {
+		if v, err := time.Parse(format, value); err == nil {
+			return ClockTimeOf(v), true
+		}
+	}
+	return ClockTime{}, false
+}
+
+func parseClockRoundConfig(roundTag string) *clockRoundConfig {
+	parts := strings.Split(strings.ToLower(roundTag), ":")
+
+	granularity, err := time.ParseDuration(strings.TrimSpace(parts[0]))
+	if err != nil || granularity < time.Second || granularity > 24*time.Hour {
+		panic("invalid meta_round for TimeOfDay: " + roundTag)
+	}
+
+	direction := DirectionDown
+	if len(parts) > 1 {
+		if validDir, exists := validDirections[strings.TrimSpace(parts[1])]; exists {
+			direction = validDir
+		}
+	}
+
+	return &clockRoundConfig{granularity: granularity, direction: direction}
+}
+
+func (r *clockRoundConfig) apply(c ClockTime) ClockTime {
+	if r == nil {
+		return c
+	}
+
+	step := int(r.granularity / time.Second)
+	s := c.seconds()
+	down := s - s%step
+
+	switch r.direction {
+	case DirectionUp:
+		if down != s {
+			return clockTimeFromSeconds(down + step)
+		}
+	case DirectionNearest:
+		if s-down > step/2 {
+			return clockTimeFromSeconds(down + step)
+		}
+	}
+	return clockTimeFromSeconds(down)
+}
+
+func newClockTimeLimit(raw string, opts *TimeOfDayOptions) *clockTimeLimit {
+	if raw == "" {
+		return nil
+	}
+
+	value, exclusive := parseExclusivePrefix(raw)
+
+	c, ok := parseClockTime(value, opts.Format)
+	if !ok {
+		panic("invalid time of day limit " + raw)
+	}
+
+	return &clockTimeLimit{value: opts.Round.apply(c), exclusive: exclusive}
+}
@@ -0,0 +1,157 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withTimeOfDay struct {
+	A TimeOfDay `meta_required:"true"`
+}
+
+var withTimeOfDayDecoder = NewDecoder(&withTimeOfDay{})
+
+func TestTimeOfDaySuccess(t *testing.T) {
+	for input, expected := range map[string]ClockTime{
+		"09:30":    {9, 30, 0},
+		"9:30":     {9, 30, 0},
+		"17:45:10": {17, 45, 10},
+		"5:30pm":   {17, 30, 0},
+		"5:30PM":   {17, 30, 0},
+		"9:30am":   {9, 30, 0},
+		"12:05AM":  {0, 5, 0},
+		"00:00":    {0, 0, 0},
+		"23:59:59": {23, 59, 59},
+	} {
+		var inputs withTimeOfDay
+		e := withTimeOfDayDecoder.DecodeValues(&inputs, url.Values{"a": {input}})
+		assertEqual(t, e, ErrorHash(nil), input)
+		assertEqual(t, inputs.A.Val, expected, input)
+		assertEqual(t, inputs.A.Present, true, input)
+	}
+
+	var inputs withTimeOfDay
+	e := withTimeOfDayDecoder.DecodeJSON(&inputs, []byte(`{"a":"08:15"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, ClockTime{8, 15, 0})
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestTimeOfDayInvalid(t *testing.T) {
+	var inputs withTimeOfDay
+
+	for _, bad := range []string{"24:00", "17:60", "13:30pm", "noon", "2024-01-01T09:30:00Z"} {
+		e := withTimeOfDayDecoder.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrTimeOfDay}, bad)
+	}
+
+	e := withTimeOfDayDecoder.DecodeJSON(&inputs, []byte(`{"a":930}`))
+	assertEqual(t, e, ErrorHash{"a": ErrTimeOfDay})
+
+	e = withTimeOfDayDecoder.DecodeJSON(&inputs, []byte(`{"a":" "}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withTimeOfDayDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestTimeOfDayFormat(t *testing.T) {
+	var inputs struct {
+		A TimeOfDay `meta_format:"TimeOnly"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"09:30:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"09:30"}})
+	assertEqual(t, e, ErrorHash{"a": ErrTimeOfDay})
+}
+
+func TestTimeOfDayRange(t *testing.T) {
+	var inputs struct {
+		A TimeOfDay `meta_min:"09:00" meta_max:"!5:00PM"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"09:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"16:59:59"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"08:59:59"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"17:00"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+}
+
+func TestTimeOfDayRounding(t *testing.T) {
+	var inputs struct {
+		Down    TimeOfDay `meta_round:"1m"`
+		Nearest TimeOfDay `meta_round:"15m:nearest"`
+		Up      TimeOfDay `meta_round:"30m:up"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"down": {"09:30:59"}, "nearest": {"09:37:31"}, "up": {"09:30"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Down.Val, ClockTime{9, 30, 0})
+	assertEqual(t, inputs.Nearest.Val, ClockTime{9, 45, 0})
+	assertEqual(t, inputs.Up.Val, ClockTime{9, 30, 0})
+
+	e = d.DecodeValues(&inputs, url.Values{"nearest": {"09:37:30"}, "up": {"23:45"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.Nearest.Val, ClockTime{9, 30, 0})
+	assertEqual(t, inputs.Up.Val, ClockTime{0, 0, 0})
+}
+
+func TestClockTime(t *testing.T) {
+	c := NewClockTime(9, 75, 0)
+	assertEqual(t, c, ClockTime{10, 15, 0})
+	assertEqual(t, c.String(), "10:15:00")
+	assertEqual(t, c.SinceMidnight(), 10*time.Hour+15*time.Minute)
+	assert(t, c.Before(ClockTime{10, 15, 1}))
+	assert(t, c.After(ClockTime{10, 14, 59}))
+
+	ny, _ := time.LoadLocation("America/New_York")
+	on := c.On(CivilDate{2024, time.July, 4}, ny)
+	assert(t, on.Equal(time.Date(2024, 7, 4, 14, 15, 0, 0, time.UTC)))
+}
+
+func TestTimeOfDaySQL(t *testing.T) {
+	tod := NewTimeOfDay(ClockTime{17, 45, 10})
+
+	v, err := tod.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "17:45:10")
+
+	var scanned TimeOfDay
+	err = scanned.Scan([]byte("17:45:10.123456"))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned, tod)
+
+	err = scanned.Scan(time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC))
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Val, ClockTime{8, 0, 0})
+
+	err = scanned.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, scanned.Null, true)
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	tod := NewTimeOfDay(ClockTime{9, 5, 0})
+
+	bs, err := json.Marshal(tod)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"09:05:00"`)
+
+	var decoded TimeOfDay
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded, tod)
+}
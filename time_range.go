@@ -0,0 +1,258 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//
+// Core Types and Structures
+//
+
+// TimeInterval is a closed interval of time, From <= To.
+type TimeInterval struct {
+	From time.Time
+	To   time.Time
+}
+
+type TimeRange struct {
+	Val TimeInterval
+	Nullity
+	Presence
+	Path string
+	// format is the output format of both ends, see FormattedTime.
+	format string
+}
+
+// TimeRangeOptions parse both ends of the range like a Time field with the same tags, so meta_format,
+// meta_min, meta_max, meta_round, meta_location and friends apply to From and To.
+type TimeRangeOptions struct {
+	*TimeOptions
+	// MaxSpan is the longest allowed To - From.
+	// Configured via meta_max_span tag, written like Duration input.
+	// Default: 0 (no limit)
+	// Examples: `meta_max_span:"90d"`, `meta_max_span:"P2W"`, `meta_max_span:"12h"`
+	MaxSpan time.Duration
+}
+
+// timeRangeSeparator separates both ends in the single string form, eg "2024-01-01..2024-02-01".
+const timeRangeSeparator = ".."
+
+//
+// Constructors
+//
+
+func NewTimeRange(from, to time.Time) TimeRange {
+	return TimeRange{TimeInterval{from, to}, Nullity{false}, Presence{true}, "", ""}
+}
+
+//
+// TimeInterval Methods
+//
+
+func (i TimeInterval) Duration() time.Duration {
+	return i.To.Sub(i.From)
+}
+
+// Contains reports whether t is within the interval, both ends included.
+func (i TimeInterval) Contains(t time.Time) bool {
+	return !t.Before(i.From) && !t.After(i.To)
+}
+
+//
+// Core TimeRange Methods
+//
+
+func (r *TimeRange) ParseOptions(tag reflect.StructTag) interface{} {
+	var tempT Time
+	opts := &TimeRangeOptions{
+		TimeOptions: tempT.ParseOptions(tag).(*TimeOptions),
+	}
+
+	if span := tag.Get("meta_max_span"); span != "" {
+		d, ok := parseDuration(span)
+		if !ok || d <= 0 {
+			panic("invalid meta_max_span " + span)
+		}
+		opts.MaxSpan = d
+	}
+
+	return opts
+}
+
+func (r *TimeRange) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	r.Path = path
+	if i == nil {
+		return r.FormValue("", options)
+	}
+
+	opts := options.(*TimeRangeOptions)
+
+	switch value := i.(type) {
+	case string:
+		return r.FormValue(value, options)
+	case map[string]interface{}:
+		from, fromOk := value["from"]
+		to, toOk := value["to"]
+		if !fromOk && !toOk {
+			return ErrTimeRange
+		}
+		var errs ErrorHash
+		if !fromOk {
+			errs = addError(errs, "from", ErrRequired)
+		}
+		if !toOk {
+			errs = addError(errs, "to", ErrRequired)
+		}
+		if errs != nil {
+			return errs
+		}
+		return r.parseEnds(from, to, opts)
+	}
+
+	return ErrTimeRange
+}
+
+func (r *TimeRange) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*TimeRangeOptions)
+
+	if strings.TrimSpace(value) == "" {
+		return r.handleEmptyValue(opts)
+	}
+
+	from, to, ok := strings.Cut(value, timeRangeSeparator)
+	if !ok {
+		return ErrTimeRange
+	}
+	return r.parseEnds(strings.TrimSpace(from), strings.TrimSpace(to), opts)
+}
+
+// Value stores the range as a closed Postgres range literal, which tstzrange columns accept.
+func (r TimeRange) Value() (driver.Value, error) {
+	if r.Present && !r.Null {
+		return "[" + r.Val.From.Format(time.RFC3339Nano) + "," + r.Val.To.Format(time.RFC3339Nano) + "]", nil
+	}
+	return nil, nil
+}
+
+// MarshalJSON renders {"from": ..., "to": ...}, each end in the output format of the field.
+func (r TimeRange) MarshalJSON() ([]byte, error) {
+	if r.Present && !r.Null {
+		from, to := r.ends()
+		return MetaJson.Marshal(map[string]FormattedTime{"from": from, "to": to})
+	}
+	return nullString, nil
+}
+
+// MarshalText renders the single string form "<from>..<to>" for form and query string encoders.
+func (r TimeRange) MarshalText() ([]byte, error) {
+	if !r.Present || r.Null {
+		return []byte{}, nil
+	}
+	from, to := r.ends()
+	fromText, err := from.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	toText, err := to.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(string(fromText) + timeRangeSeparator + string(toText)), nil
+}
+
+func (r *TimeRange) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		r.Nullity = Nullity{true}
+		return nil
+	}
+
+	ends := struct {
+		From FormattedTime `json:"from"`
+		To   FormattedTime `json:"to"`
+	}{FormattedTime{Format: r.format}, FormattedTime{Format: r.format}}
+	err := MetaJson.Unmarshal(b, &ends)
+	if err != nil {
+		return err
+	}
+
+	r.Val = TimeInterval{ends.From.Val, ends.To.Val}
+	r.Presence = Presence{true}
+	r.Nullity = Nullity{false}
+	return nil
+}
+
+//
+// Value Handling Methods
+//
+
+func (r *TimeRange) handleEmptyValue(opts *TimeRangeOptions) Errorable {
+	if opts.Null {
+		r.Present = true
+		r.Null = true
+		return nil
+	}
+	if opts.Required {
+		return ErrBlank
+	}
+	if !opts.DiscardBlank {
+		r.Present = true
+		return ErrBlank
+	}
+	return nil
+}
+
+// parseEnds decodes both ends as required Time values, reporting their errors under "from" and "to",
+// then checks their order and span.
+func (r *TimeRange) parseEnds(from, to interface{}, opts *TimeRangeOptions) Errorable {
+	endOpts := *opts.TimeOptions
+	endOpts.Required = true
+	endOpts.Null = false
+
+	var fromTime, toTime Time
+	var errs ErrorHash
+	if err := fromTime.JSONValue(r.Path+".from", from, &endOpts); err != nil {
+		errs = addError(errs, "from", err)
+	}
+	if err := toTime.JSONValue(r.Path+".to", to, &endOpts); err != nil {
+		errs = addError(errs, "to", err)
+	}
+	if errs != nil {
+		return errs
+	}
+
+	if fromTime.Val.After(toTime.Val) {
+		return ErrTimeRange
+	}
+	if opts.MaxSpan > 0 && toTime.Val.Sub(fromTime.Val) > opts.MaxSpan {
+		return ErrTimeRangeSpan
+	}
+
+	r.Val = TimeInterval{fromTime.Val, toTime.Val}
+	r.Present = true
+	r.format = opts.OutputFormat
+	return nil
+}
+
+func (r TimeRange) ends() (FormattedTime, FormattedTime) {
+	return NewFormattedTime(NewTime(r.Val.From), r.format), NewFormattedTime(NewTime(r.Val.To), r.format)
+}
+
+//
+// Location Methods
+//
+
+// withSource resolves meta_location_field for both ends.
+func (opts *TimeRangeOptions) withSource(src source) interface{} {
+	timeOpts := opts.TimeOptions.withSource(src).(*TimeOptions)
+	if timeOpts == opts.TimeOptions {
+		return opts
+	}
+
+	resolved := *opts
+	resolved.TimeOptions = timeOpts
+	return &resolved
+}
@@ -0,0 +1,137 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withTimeRange struct {
+	A TimeRange `meta_required:"true" meta_format:"DateOnly,RFC3339,expression" meta_max_span:"90d"`
+}
+
+var withTimeRangeDecoder = NewDecoder(&withTimeRange{})
+
+func TestTimeRangeSuccess(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var inputs withTimeRange
+	e := withTimeRangeDecoder.DecodeJSON(&inputs, []byte(`{"a":{"from":"2024-01-01","to":"2024-02-01T00:00:00Z"}}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, TimeInterval{jan, feb})
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.A.Path, "a")
+
+	inputs = withTimeRange{}
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a.from": {"2024-01-01"}, "a.to": {"2024-02-01"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, TimeInterval{jan, feb})
+
+	inputs = withTimeRange{}
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01..2024-02-01"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, TimeInterval{jan, feb})
+	assertEqual(t, inputs.A.Val.Duration(), 31*24*time.Hour)
+	assert(t, inputs.A.Val.Contains(feb))
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01..2024-01-01"}})
+	assertEqual(t, e, ErrorHash(nil))
+}
+
+func TestTimeRangeInvalid(t *testing.T) {
+	var inputs withTimeRange
+
+	e := withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-02-01..2024-01-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrTimeRange})
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01..2024-04-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrTimeRangeSpan})
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrTimeRange})
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"soon..2024-01-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorHash{"from": ErrTime}})
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01.."}})
+	assertEqual(t, e, ErrorHash{"a": ErrorHash{"to": ErrBlank}})
+
+	e = withTimeRangeDecoder.DecodeValues(&inputs, url.Values{"a.from": {"2024-01-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorHash{"to": ErrRequired}})
+
+	e = withTimeRangeDecoder.DecodeJSON(&inputs, []byte(`{"a":{"since":"2024-01-01"}}`))
+	assertEqual(t, e, ErrorHash{"a": ErrTimeRange})
+
+	e = withTimeRangeDecoder.DecodeJSON(&inputs, []byte(`{"a":5}`))
+	assertEqual(t, e, ErrorHash{"a": ErrTimeRange})
+
+	e = withTimeRangeDecoder.DecodeJSON(&inputs, []byte(`{"a":""}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withTimeRangeDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+}
+
+func TestTimeRangeTimeOptions(t *testing.T) {
+	asOf := time.Date(2024, 3, 13, 14, 30, 0, 0, time.UTC)
+
+	var inputs struct {
+		A TimeRange `meta_min:"start_of_year" meta_max:"now" meta_round:"day" meta_location:"America/New_York"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(asOf)})
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"7_days_ago..today"}})
+	assertEqual(t, e, ErrorHash(nil))
+	ny, _ := time.LoadLocation("America/New_York")
+	assert(t, inputs.A.Val.From.Equal(time.Date(2024, 3, 6, 0, 0, 0, 0, ny)))
+	assert(t, inputs.A.Val.To.Equal(time.Date(2024, 3, 13, 0, 0, 0, 0, ny)))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2023-12-31T12:00:00-05:00..today"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorHash{"from": ErrMin}})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"today..tomorrow"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorHash{"to": ErrMax}})
+}
+
+func TestTimeRangeLocationField(t *testing.T) {
+	var inputs struct {
+		TimeZone String
+		A        TimeRange `meta_format:"DateTime" meta_location_field:"time_zone"`
+	}
+
+	e := NewDecoder(&inputs).DecodeJSON(&inputs, []byte(`{"time_zone":"Asia/Tokyo","a":"2024-01-01 09:00:00..2024-01-01 18:00:00"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.From.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assertEqual(t, inputs.A.Val.Duration(), 9*time.Hour)
+}
+
+func TestTimeRangeJSON(t *testing.T) {
+	var inputs struct {
+		A TimeRange `meta_format:"DateOnly" meta_output_format:"DateOnly"`
+	}
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"a": {"2024-01-01..2024-01-31"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	bs, err := json.Marshal(inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"A":{"from":"2024-01-01","to":"2024-01-31"}}`)
+
+	text, err := inputs.A.MarshalText()
+	assertEqual(t, err, nil)
+	assertEqual(t, string(text), "2024-01-01..2024-01-31")
+
+	err = json.Unmarshal([]byte(`{"A":{"from":"2025-01-01","to":"2025-01-02"}}`), &inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, inputs.A.Val.Duration(), 24*time.Hour)
+
+	r := NewTimeRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	v, err := r.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z]")
+
+	bs, err = json.Marshal(TimeRange{})
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "null")
+}
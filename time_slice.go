@@ -0,0 +1,309 @@
+package meta
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//
+// TimeSlice
+//
+
+type TimeSlice struct {
+	Presence
+	Nullity
+	Val  []time.Time
+	Path string
+	// sqlFormat is how Value stores the slice, "" for SQLFormatArray
+	sqlFormat string
+	// format is the output format of the elements, see FormattedTime
+	format string
+}
+
+type TimeSliceOptions struct {
+	*TimeOptions
+	*SliceOptions
+}
+
+func (i *TimeSlice) ParseOptions(tag reflect.StructTag) interface{} {
+	var tempT Time
+	opts := tempT.ParseOptions(tag)
+
+	return &TimeSliceOptions{
+		TimeOptions:  opts.(*TimeOptions),
+		SliceOptions: ParseSliceOptions(tag),
+	}
+}
+
+// withSource resolves meta_location_field for every element.
+func (opts *TimeSliceOptions) withSource(src source) interface{} {
+	timeOpts := opts.TimeOptions.withSource(src).(*TimeOptions)
+	if timeOpts == opts.TimeOptions {
+		return opts
+	}
+
+	resolved := *opts
+	resolved.TimeOptions = timeOpts
+	return &resolved
+}
+
+func (n *TimeSlice) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	opts := options.(*TimeSliceOptions)
+	timeOpts := opts.TimeOptions
+	sliceOpts := opts.SliceOptions
+
+	n.Path = path
+	n.Val = nil
+	n.sqlFormat = sliceOpts.SQLFormat
+	n.format = timeOpts.OutputFormat
+	n.Present = true
+	n.Null = false
+
+	if i == nil {
+		n.Null = true
+		if sliceOpts.DiscardBlank {
+			n.Present = false
+			return nil
+		} else if sliceOpts.Null {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	var errorsInSlice ErrorSlice
+	switch value := i.(type) {
+	case string:
+		return n.FormValue(value, options)
+	case []string:
+		return n.formValues(value, options)
+	case []interface{}:
+		n.Val = []time.Time{}
+
+		if sliceOpts.MinLengthPresent && len(value) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(value) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(value) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+
+		for _, v := range value {
+			var tv Time
+			if err := tv.JSONValue("", v, timeOpts); err != nil {
+				errorsInSlice = append(errorsInSlice, err)
+			} else {
+				errorsInSlice = append(errorsInSlice, nil)
+				n.Val = append(n.Val, tv.Val)
+			}
+		}
+		if errorsInSlice.Len() > 0 {
+			return errorsInSlice
+		}
+
+		var err Errorable
+		if n.Val, err = constrainValues(n.Val, sliceOpts); err != nil {
+			return err
+		}
+
+		if sliceOpts.MinLengthPresent && len(n.Val) < sliceOpts.MinLength {
+			return ErrMinLength
+		}
+
+		if sliceOpts.MaxLengthPresent && len(n.Val) > sliceOpts.MaxLength {
+			return ErrMaxLength
+		}
+
+		if len(n.Val) == 0 {
+			if sliceOpts.DiscardBlank {
+				n.Present = false
+				return nil
+			} else if sliceOpts.Blank {
+				return nil
+			}
+			return ErrBlank
+		}
+	}
+	return nil
+}
+
+func (i *TimeSlice) FormValue(value string, options interface{}) Errorable {
+	return i.formValues([]string{value}, options)
+}
+
+// formValues decodes the values of a form key, repeated keys giving several values.
+func (i *TimeSlice) formValues(values []string, options interface{}) Errorable {
+	var tempT Time
+
+	opts := options.(*TimeSliceOptions)
+	timeOpts := opts.TimeOptions
+	sliceOpts := opts.SliceOptions
+
+	i.Val = []time.Time{}
+	i.sqlFormat = sliceOpts.SQLFormat
+	i.format = timeOpts.OutputFormat
+	i.Present = true
+	i.Null = false
+
+	values = append([]string(nil), values...)
+	blank := true
+	for j, value := range values {
+		values[j] = strings.TrimSpace(value)
+		blank = blank && values[j] == ""
+	}
+
+	if blank {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	strs, err := sliceOpts.split(values)
+	if err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(strs) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(strs) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	var errorsInSlice ErrorSlice
+	for _, s := range strs {
+		tempT.Val = time.Time{}
+		if err := tempT.FormValue(strings.TrimSpace(s), timeOpts); err != nil {
+			errorsInSlice = append(errorsInSlice, err)
+		} else {
+			errorsInSlice = append(errorsInSlice, nil)
+			i.Val = append(i.Val, tempT.Val)
+		}
+	}
+
+	if errorsInSlice.Len() > 0 {
+		return errorsInSlice
+	}
+
+	if i.Val, err = constrainValues(i.Val, sliceOpts); err != nil {
+		return err
+	}
+
+	if sliceOpts.MinLengthPresent && len(i.Val) < sliceOpts.MinLength {
+		return ErrMinLength
+	}
+
+	if sliceOpts.MaxLengthPresent && len(i.Val) > sliceOpts.MaxLength {
+		return ErrMaxLength
+	}
+
+	if len(i.Val) == 0 {
+		if sliceOpts.DiscardBlank {
+			i.Present = false
+			return nil
+		} else if sliceOpts.Blank {
+			return nil
+		}
+		return ErrBlank
+	}
+
+	return nil
+}
+
+// Value stores the slice as a Postgres array literal, eg "{2024-01-01T00:00:00Z}", or as a JSON array when the
+// field's meta_sql_format is "json".
+func (s TimeSlice) Value() (driver.Value, error) {
+	if s.Null || (!s.Present && s.Val == nil) {
+		return nil, nil
+	}
+	values := make([]driver.Value, len(s.Val))
+	for i, v := range s.Val {
+		values[i] = v
+	}
+	return encodeSQLSlice(values, s.sqlFormat)
+}
+
+// WithSQLFormat returns a copy of s that Value stores in format, SQLFormatArray or SQLFormatJSON.
+func (s TimeSlice) WithSQLFormat(format string) TimeSlice {
+	s.sqlFormat = parseSQLFormat(format)
+	return s
+}
+
+// Scan reads Postgres timestamp arrays and JSON arrays of timestamps. The SQL and output formats
+// of s are kept.
+func (s *TimeSlice) Scan(src interface{}) error {
+	if src == nil {
+		*s = TimeSlice{Nullity: Nullity{true}, Presence: Presence{true}, sqlFormat: s.sqlFormat, format: s.format}
+		return nil
+	}
+
+	elems, err := ScanSlice[Time](src)
+	if err != nil {
+		return err
+	}
+	val := make([]time.Time, len(elems))
+	for i, elem := range elems {
+		if elem.Null {
+			return fmt.Errorf("meta: NULL element %d in TimeSlice", i)
+		}
+		val[i] = elem.Val
+	}
+	*s = TimeSlice{Val: val, Presence: Presence{true}, sqlFormat: s.sqlFormat, format: s.format}
+	return nil
+}
+
+// WithOutputFormat returns a copy of s that MarshalJSON renders in format, a predefined name like "DateOnly", a layout or an epoch format.
+func (s TimeSlice) WithOutputFormat(format string) TimeSlice {
+	s.format = lookupTimeFormat(format)
+	return s
+}
+
+// MarshalJSON renders the elements in the output format of the field, like FormattedTime.
+func (s TimeSlice) MarshalJSON() ([]byte, error) {
+	if len(s.Val) > 0 {
+		elems := make([]FormattedTime, len(s.Val))
+		for i, v := range s.Val {
+			elems[i] = NewFormattedTime(NewTime(v), s.format)
+		}
+		return MetaJson.Marshal(elems)
+	}
+	return nullString, nil
+}
+
+// UnmarshalJSON reads the elements like FormattedTime.UnmarshalJSON, with the output format of s.
+func (s *TimeSlice) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	err := MetaJson.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	var value []time.Time
+	for _, b := range raw {
+		elem := FormattedTime{Format: s.format}
+		if err := elem.UnmarshalJSON(b); err != nil {
+			return err
+		}
+		value = append(value, elem.Val)
+	}
+	*s = TimeSlice{Val: value, format: s.format}
+	return nil
+}
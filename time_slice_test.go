@@ -0,0 +1,81 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type withTimeSlice struct {
+	A TimeSlice `meta_format:"DateOnly" meta_output_format:"DateOnly" meta_min:"2024-01-01"`
+}
+
+var withTimeSliceDecoder = NewDecoder(&withTimeSlice{})
+
+func TestTimeSliceSuccess(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var inputs withTimeSlice
+	e := withTimeSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01, 2024-02-01"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []time.Time{jan, feb})
+
+	e = withTimeSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":["2024-02-01"]}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, []time.Time{feb})
+
+	bs, err := json.Marshal(inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"A":["2024-02-01"]}`)
+
+	err = json.Unmarshal([]byte(`{"A":["2024-01-01","2024-02-01"]}`), &inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, inputs.A.Val, []time.Time{jan, feb})
+}
+
+func TestTimeSliceInvalid(t *testing.T) {
+	var inputs withTimeSlice
+
+	e := withTimeSliceDecoder.DecodeValues(&inputs, url.Values{"a": {"2024-01-01,soon,2023-12-31"}})
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{nil, ErrTime, ErrMin}})
+
+	e = withTimeSliceDecoder.DecodeJSON(&inputs, []byte(`{"a":[true]}`))
+	assertEqual(t, e, ErrorHash{"a": ErrorSlice{ErrTime}})
+}
+
+func TestTimeSliceDecoderOptions(t *testing.T) {
+	asOf := time.Date(2024, 3, 13, 14, 30, 0, 0, time.UTC)
+
+	var inputs struct {
+		TimeZone String
+		A        TimeSlice `meta_format:"expression,DateTime" meta_location_field:"time_zone"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(asOf)})
+
+	e := d.DecodeJSON(&inputs, []byte(`{"time_zone":"Asia/Tokyo","a":"today,2024-01-01 09:00:00"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	assert(t, inputs.A.Val[0].Equal(time.Date(2024, 3, 13, 0, 0, 0, 0, tokyo)))
+	assert(t, inputs.A.Val[1].Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestTimeSliceSQL(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	v, err := TimeSlice{Val: []time.Time{at}}.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "{2024-03-05T14:30:00Z}")
+
+	var scanned TimeSlice
+	err = scanned.Scan([]byte(`{"2024-03-05 14:30:00+00"}`))
+	assertEqual(t, err, nil)
+	assertEqual(t, len(scanned.Val), 1)
+	assert(t, scanned.Val[0].Equal(at))
+
+	scanned = scanned.WithOutputFormat("DateOnly")
+	bs, err := json.Marshal(scanned)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `["2024-03-05"]`)
+}
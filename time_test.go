@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"encoding/json"
 	"net/url"
 	"testing"
 	"time"
@@ -826,3 +827,282 @@ func TestTimeExclusiveBoundaryWithRounding(t *testing.T) {
 
 	assertEqual(t, e, ErrorHash{"a": ErrMax}, "should fail because rounded value is outside max boundary")
 }
+
+func TestTimeLocation(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+
+	var inputs struct {
+		A Time `meta_format:"DateTime" meta_location:"America/New_York"`
+		B Time `meta_format:"DateTime"`
+		C Time `meta_format:"RFC3339" meta_location:"America/New_York"`
+	}
+
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{
+		"a": {"2024-01-15 09:00:00"},
+		"b": {"2024-01-15 09:00:00"},
+		"c": {"2024-01-15T09:00:00Z"},
+	})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)))
+	assertEqual(t, inputs.A.Val.Location(), ny)
+	assert(t, inputs.B.Val.Equal(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)))
+	// an explicit zone in the input wins over the location
+	assert(t, inputs.C.Val.Equal(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestTimeLocationExpressions(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	var inputs struct {
+		A Time `meta_location:"Asia/Tokyo"`
+		B Time `meta_location:"Asia/Tokyo" meta_round:"day"`
+	}
+
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"a": {"today"}, "b": {"2024-01-15T20:00:00Z"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	now := time.Now().In(tokyo)
+	assert(t, inputs.A.Val.Equal(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tokyo)))
+	assertEqual(t, inputs.A.Val.Location(), tokyo)
+
+	// 20:00 UTC is 05:00 the next day in Tokyo, so the value is rounded down to midnight there
+	assert(t, inputs.B.Val.Equal(time.Date(2024, 1, 16, 0, 0, 0, 0, tokyo)))
+}
+
+func TestTimeLocationField(t *testing.T) {
+	var inputs struct {
+		TimeZone String
+		A        Time `meta_format:"DateTime" meta_location_field:"time_zone" meta_location:"UTC" meta_max:"2024-01-15 12:00:00"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"time_zone": {"Europe/Paris"}, "a": {"2024-01-15 09:00:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)))
+
+	e = d.DecodeJSON(&inputs, []byte(`{"time_zone":"America/Los_Angeles","a":"2024-01-15 09:00:00"}`))
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+
+	// an invalid zone is an error
+	e = d.DecodeJSON(&inputs, []byte(`{"time_zone":"Mars/Olympus","a":"2024-01-15 09:00:00"}`))
+	assertEqual(t, e, ErrorHash{"a": ErrLocation})
+
+	e = d.DecodeValues(&inputs, url.Values{"time_zone": {"Local"}, "a": {"2024-01-15 09:00:00"}})
+	assertEqual(t, e, ErrorHash{"a": ErrLocation})
+
+	// a missing or blank zone falls back to meta_location
+	e = d.DecodeJSON(&inputs, []byte(`{"a":"2024-01-15 10:00:00"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)))
+
+	e = d.DecodeValues(&inputs, url.Values{"time_zone": {" "}, "a": {"2024-01-15 11:00:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)))
+}
+
+func TestTimeUTC(t *testing.T) {
+	var inputs struct {
+		A Time `meta_utc:"true"`
+		B Time `meta_utc:"true" meta_location:"America/New_York" meta_round:"day"`
+	}
+
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"a": {"2024-01-15T09:00:00+02:00"}, "b": {"2024-01-15T03:00:00Z"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, time.Date(2024, 1, 15, 7, 0, 0, 0, time.UTC))
+	// rounded in New York (2024-01-14 22:00 -> 2024-01-14 00:00 EST), then converted
+	assertEqual(t, inputs.B.Val, time.Date(2024, 1, 14, 5, 0, 0, 0, time.UTC))
+}
+
+func TestTimeDecoderOptionsLocation(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+
+	var inputs struct {
+		A Time `meta_format:"DateTime" meta_min:"2024-01-15 00:00:00"`
+		B Time `meta_format:"DateTime" meta_location:"UTC"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Location: ny, UTC: true})
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"2024-01-15 09:00:00"}, "b": {"2024-01-15 09:00:00"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val, time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC))
+	assertEqual(t, inputs.B.Val, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+
+	// the limit is midnight in New York
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2024-01-14 23:59:59"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin})
+}
+
+func TestTimeCompoundExpressions(t *testing.T) {
+	// Wednesday
+	asOf := time.Date(2024, 3, 13, 14, 30, 15, 0, time.UTC)
+
+	var inputs struct {
+		A Time
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(asOf)})
+
+	for expression, expected := range map[string]time.Time{
+		"start_of_day":            time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+		"end_of_day":              time.Date(2024, 3, 13, 23, 59, 59, 999999999, time.UTC),
+		"start_of_week":           time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		"end_of_week":             time.Date(2024, 3, 17, 23, 59, 59, 999999999, time.UTC),
+		"start_of_month":          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"end_of_month":            time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		"start_of_year":           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"end_of_year":             time.Date(2024, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		"start_of_hour":           time.Date(2024, 3, 13, 14, 0, 0, 0, time.UTC),
+		"end_of_minute":           time.Date(2024, 3, 13, 14, 30, 59, 999999999, time.UTC),
+		"last_monday":             time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		"last_wednesday":          time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+		"last_thu":                time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC),
+		"next_friday":             time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		"next_wednesday":          time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		"next_tue":                time.Date(2024, 3, 19, 0, 0, 0, 0, time.UTC),
+		"2_days_ago_at_09:00":     time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		"tomorrow_at_17:45:30":    time.Date(2024, 3, 14, 17, 45, 30, 0, time.UTC),
+		"next_friday_at_8:15":     time.Date(2024, 3, 15, 8, 15, 0, 0, time.UTC),
+		"now-3h":                  time.Date(2024, 3, 13, 11, 30, 15, 0, time.UTC),
+		"today+1d":                time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+		"now+1h30m":               time.Date(2024, 3, 13, 16, 0, 15, 0, time.UTC),
+		"start_of_month-1mo":      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		"today-1y+2w":             time.Date(2023, 3, 27, 0, 0, 0, 0, time.UTC),
+		"1_day_ago-15s":           time.Date(2024, 3, 12, 14, 30, 0, 0, time.UTC),
+		"last_monday_at_09:00+1d": time.Date(2024, 3, 12, 9, 0, 0, 0, time.UTC),
+		"@1700000000":             time.Unix(1700000000, 0),
+		"@1700000000.25":          time.Unix(1700000000, 250000000),
+		"@-1.5":                   time.Unix(-2, 500000000),
+		"@0+1d":                   time.Unix(86400, 0),
+	} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {expression}})
+		assertEqual(t, e, ErrorHash(nil), expression)
+		assert(t, inputs.A.Val.Equal(expected), "%s: expected %s, got %s", expression, expected, inputs.A.Val)
+	}
+
+	for _, bad := range []string{"start_of_decade", "last_funday", "today_at_25:00", "now-3x", "soon+1d", "@", "@abc", "now+"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrTime}, bad)
+	}
+}
+
+func TestTimeCompoundExpressionLimits(t *testing.T) {
+	asOf := time.Date(2024, 3, 13, 14, 30, 0, 0, time.UTC)
+
+	var inputs struct {
+		A Time `meta_min:"start_of_month" meta_max:"!next_monday_at_09:00"`
+		B Time `meta_min:"now-90d" meta_max:"@1710345600"`
+	}
+	d := NewDecoderWithOptions(&inputs, DecoderOptions{Clock: FixedClock(asOf)})
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"2024-03-01T00:00:00Z"}, "b": {"2023-12-15T00:00:00Z"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2024-02-29T23:59:59Z"}, "b": {"2023-12-14T00:00:00Z"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMin, "b": ErrMin})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"2024-03-18T09:00:00Z"}, "b": {"2024-03-14T00:00:00Z"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax, "b": ErrMax})
+
+	var dates struct {
+		A Date `meta_min:"start_of_month" meta_max:"end_of_month"`
+	}
+	d = NewDecoderWithOptions(&dates, DecoderOptions{Clock: FixedClock(asOf)})
+
+	e = d.DecodeValues(&dates, url.Values{"a": {"2024-03-31"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&dates, url.Values{"a": {"2024-04-01"}})
+	assertEqual(t, e, ErrorHash{"a": ErrMax})
+
+	e = d.DecodeValues(&dates, url.Values{"a": {"last_friday"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, dates.A.Val, CivilDate{2024, time.March, 8})
+}
+
+func TestTimeEpoch(t *testing.T) {
+	var inputs struct {
+		Seconds Time `meta_format:"unix"`
+		Millis  Time `meta_format:"unix_ms,RFC3339"`
+		Nanos   Time `meta_format:"unix_ns" meta_min:"@1704067200"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"seconds":1700000000,"millis":1700000000250,"nanos":"1710000000000000001"}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.Seconds.Val.Equal(time.Unix(1700000000, 0)))
+	assertEqual(t, inputs.Seconds.Val.Location(), time.UTC)
+	assert(t, inputs.Millis.Val.Equal(time.Unix(1700000000, 250000000)))
+	assert(t, inputs.Nanos.Val.Equal(time.Unix(1710000000, 1)))
+
+	e = d.DecodeValues(&inputs, url.Values{"seconds": {"1700000000.5"}, "millis": {"2024-01-01T00:00:00Z"}, "nanos": {"1700000000000000000"}})
+	assertEqual(t, e, ErrorHash{"nanos": ErrMin})
+	assert(t, inputs.Seconds.Val.Equal(time.Unix(1700000000, 500000000)))
+	assert(t, inputs.Millis.Val.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	e = d.DecodeValues(&inputs, url.Values{"seconds": {"-1.25"}, "millis": {"-1500"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.Seconds.Val.Equal(time.Unix(-2, 750000000)))
+	assert(t, inputs.Millis.Val.Equal(time.Unix(-2, 500000000)))
+
+	e = d.DecodeJSON(&inputs, []byte(`{"seconds":"soon","millis":1.7e12,"nanos":true}`))
+	assertEqual(t, e, ErrorHash{"seconds": ErrTime, "millis": ErrTime, "nanos": ErrTime})
+
+	e = withTimeDecoder.DecodeJSON(&withTime{}, []byte(`{"a":1700000000}`))
+	assertEqual(t, e, ErrorHash{"a": ErrTime})
+}
+
+func TestTimeEpochLocation(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+
+	var inputs struct {
+		A Time `meta_format:"unix" meta_location:"America/New_York" meta_round:"day"`
+	}
+	e := NewDecoder(&inputs).DecodeValues(&inputs, url.Values{"a": {"1700000000"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assert(t, inputs.A.Val.Equal(time.Date(2023, 11, 14, 0, 0, 0, 0, ny)))
+}
+
+func TestTimeEpochJSON(t *testing.T) {
+	var inputs struct {
+		Seconds Time `meta_format:"unix"`
+		Millis  Time `meta_format:"RFC3339,unix_ms"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeJSON(&inputs, []byte(`{"seconds":1700000000.75,"millis":"2024-01-01T00:00:00.123456Z"}`))
+	assertEqual(t, e, ErrorHash(nil))
+
+	// the input format doesn't change the output without meta_output_format
+	bs, err := json.Marshal(inputs)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `{"Seconds":"2023-11-14T22:13:20.75Z","Millis":"2024-01-01T00:00:00.123456Z"}`)
+
+	var plain Time
+	err = json.Unmarshal([]byte(`1700000000`), &plain)
+	assert(t, err != nil)
+}
+
+func TestTimeScan(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 30, 0, 500000000, time.UTC)
+
+	var v Time
+	err := v.Scan(at)
+	assertEqual(t, err, nil)
+	assertEqual(t, v.Val, at)
+	assertEqual(t, v.Present, true)
+
+	for _, text := range []string{"2024-03-05T14:30:00.5Z", "2024-03-05 14:30:00.5+00", "2024-03-05 14:30:00.5+00:00", "2024-03-05 14:30:00.5"} {
+		err = v.Scan([]byte(text))
+		assertEqual(t, err, nil, text)
+		assert(t, v.Val.Equal(at), text)
+	}
+
+	err = v.Scan(nil)
+	assertEqual(t, err, nil)
+	assertEqual(t, v, Time{Nullity: Nullity{true}, Presence: Presence{true}})
+
+	err = v.Scan("yesterday")
+	assert(t, err != nil)
+
+	err = v.Scan(int64(1))
+	assert(t, err != nil)
+}
@@ -0,0 +1,7 @@
+//go:build meta_tzdata
+
+package meta
+
+// Building with -tags meta_tzdata embeds a copy of the IANA time zone database, so Location
+// and meta_location work on hosts without /usr/share/zoneinfo. It adds about 450KB to the binary.
+import _ "time/tzdata"
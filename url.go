@@ -0,0 +1,221 @@
+package meta
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//
+// URL
+//
+
+type URL struct {
+	Val *url.URL
+	Nullity
+	Presence
+	Path string
+}
+
+type URLOptions struct {
+	Required     bool
+	DiscardBlank bool
+	Null         bool
+	// Absolute requires a scheme and a host, eg "https://example.com/hook".
+	// Configured via meta_absolute tag.
+	Absolute bool
+	// Relative requires a reference without scheme or host, eg "/avatars/1.png".
+	// Configured via meta_relative tag.
+	Relative bool
+	// Schemes lists the allowed schemes, compared case-insensitively.
+	// Configured via meta_scheme tag, eg `meta_scheme:"https"` or `meta_scheme:"http,https"`.
+	Schemes []string
+	// Hosts lists the allowed hosts. A leading "*." matches any subdomain, eg "*.example.com".
+	// Configured via meta_host tag.
+	Hosts []string
+	// BlockedHosts lists hosts that are never allowed, using the same matching as Hosts.
+	// Configured via meta_blocked_host tag.
+	BlockedHosts []string
+	// DisallowPrivate rejects "localhost" and literal IP hosts in private, loopback,
+	// link-local or unspecified ranges. No DNS lookup is made, so this is only a first
+	// line of defense against SSRF; the address must be checked again when dialing.
+	// Configured via meta_disallow_private tag.
+	DisallowPrivate bool
+}
+
+func NewURL(u *url.URL) URL {
+	return URL{u, Nullity{false}, Presence{true}, ""}
+}
+
+func (u *URL) ParseOptions(tag reflect.StructTag) interface{} {
+	opts := &URLOptions{
+		Required:        tag.Get("meta_required") == "true",
+		DiscardBlank:    tag.Get("meta_discard_blank") != "false",
+		Null:            tag.Get("meta_null") == "true",
+		Absolute:        tag.Get("meta_absolute") == "true",
+		Relative:        tag.Get("meta_relative") == "true",
+		Schemes:         parseLowerList(tag.Get("meta_scheme")),
+		Hosts:           parseLowerList(tag.Get("meta_host")),
+		BlockedHosts:    parseLowerList(tag.Get("meta_blocked_host")),
+		DisallowPrivate: tag.Get("meta_disallow_private") == "true",
+	}
+
+	if opts.Absolute && opts.Relative {
+		panic("meta_absolute and meta_relative are mutually exclusive")
+	}
+
+	return opts
+}
+
+func (u *URL) JSONValue(path string, i interface{}, options interface{}) Errorable {
+	u.Path = path
+	if i == nil {
+		return u.FormValue("", options)
+	}
+
+	switch value := i.(type) {
+	case string:
+		return u.FormValue(value, options)
+	}
+	return ErrURL
+}
+
+func (u *URL) FormValue(value string, options interface{}) Errorable {
+	opts := options.(*URLOptions)
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		if opts.Null {
+			u.Present = true
+			u.Null = true
+			return nil
+		}
+		if opts.Required {
+			return ErrBlank
+		}
+		if !opts.DiscardBlank {
+			u.Present = true
+			return ErrBlank
+		}
+		return nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ErrURL
+	}
+
+	isAbsolute := parsed.Scheme != "" && parsed.Host != ""
+	if opts.Absolute && !isAbsolute {
+		return ErrURL
+	}
+	if opts.Relative && (parsed.Scheme != "" || parsed.Host != "") {
+		return ErrURL
+	}
+
+	if len(opts.Schemes) > 0 && !containsString(opts.Schemes, strings.ToLower(parsed.Scheme)) {
+		return ErrURLScheme
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if len(opts.Hosts) > 0 && !matchHost(opts.Hosts, host) {
+		return ErrURLHost
+	}
+	if len(opts.BlockedHosts) > 0 && matchHost(opts.BlockedHosts, host) {
+		return ErrURLHost
+	}
+
+	if opts.DisallowPrivate && host != "" && isPrivateHost(host) {
+		return ErrPrivateAddress
+	}
+
+	u.Val = parsed
+	u.Present = true
+	return nil
+}
+
+func (u URL) Value() (driver.Value, error) {
+	if u.Present && !u.Null && u.Val != nil {
+		return u.Val.String(), nil
+	}
+	return nil, nil
+}
+
+func (u URL) MarshalJSON() ([]byte, error) {
+	if u.Present && !u.Null && u.Val != nil {
+		return MetaJson.Marshal(u.Val.String())
+	}
+	return nullString, nil
+}
+
+func (u *URL) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(nullString, b) {
+		u.Nullity = Nullity{true}
+		return nil
+	}
+
+	var s string
+	err := MetaJson.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+
+	u.Val = parsed
+	u.Presence = Presence{true}
+	u.Nullity = Nullity{false}
+	return nil
+}
+
+// matchHost reports whether host matches any of the patterns. A pattern like "*.example.com"
+// matches subdomains of example.com but not example.com itself.
+func matchHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "*.") {
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		} else if pattern == host {
+			return true
+		}
+	}
+	return false
+}
+
+// numericHostRegex matches hosts that resolvers read as IPv4 addresses in the legacy inet_aton forms,
+// eg "2130706433", "0x7f.0.0.1", "0177.0.0.1" and "127.1".
+var numericHostRegex = regexp.MustCompile(`^(?:0x[0-9a-f]*|[0-9]+)(?:\.(?:0x[0-9a-f]*|[0-9]+))*$`)
+
+// isPrivateHost reports whether host, lower-cased, is localhost or a private address. Numeric hosts
+// other than canonical IPv4 addresses are reported too, since they can't be checked reliably.
+func isPrivateHost(host string) bool {
+	host = strings.TrimRight(host, ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return numericHostRegex.MatchString(host)
+	}
+	return isPrivateAddr(addr)
+}
+
+func isPrivateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsPrivate() ||
+		addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsUnspecified()
+}
@@ -0,0 +1,183 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type withURL struct {
+	A URL `meta_required:"true"`
+}
+
+var withURLDecoder = NewDecoder(&withURL{})
+
+func TestURLSuccess(t *testing.T) {
+	var inputs withURL
+
+	e := withURLDecoder.DecodeValues(&inputs, url.Values{"a": {"https://example.com/hook?x=1"}})
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.A.Val.Host, "example.com")
+	assertEqual(t, inputs.A.Val.Path, "/hook")
+
+	inputs = withURL{}
+	e = withURLDecoder.DecodeJSON(&inputs, []byte(`{"a":" /avatars/1.png "}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Val.String(), "/avatars/1.png")
+	assertEqual(t, inputs.A.Path, "a")
+}
+
+func TestURLBlank(t *testing.T) {
+	var inputs withURL
+
+	e := withURLDecoder.DecodeValues(&inputs, url.Values{"a": {""}})
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withURLDecoder.DecodeJSON(&inputs, []byte(`{"a":null}`))
+	assertEqual(t, e, ErrorHash{"a": ErrBlank})
+
+	e = withURLDecoder.DecodeJSON(&inputs, []byte(`{}`))
+	assertEqual(t, e, ErrorHash{"a": ErrRequired})
+
+	e = withURLDecoder.DecodeJSON(&inputs, []byte(`{"a":1}`))
+	assertEqual(t, e, ErrorHash{"a": ErrURL})
+
+	e = withURLDecoder.DecodeValues(&inputs, url.Values{"a": {"http://[::1"}})
+	assertEqual(t, e, ErrorHash{"a": ErrURL})
+}
+
+func TestURLForm(t *testing.T) {
+	var inputs struct {
+		Abs URL `meta_absolute:"true"`
+		Rel URL `meta_relative:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"abs": {"https://example.com"}, "rel": {"/a/b"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"abs": {"/a/b"}, "rel": {"https://example.com"}})
+	assertEqual(t, e, ErrorHash{"abs": ErrURL, "rel": ErrURL})
+
+	e = d.DecodeValues(&inputs, url.Values{"abs": {"mailto:a@example.com"}, "rel": {"//example.com/a"}})
+	assertEqual(t, e, ErrorHash{"abs": ErrURL, "rel": ErrURL})
+}
+
+func TestURLScheme(t *testing.T) {
+	var inputs struct {
+		A URL `meta_scheme:"https"`
+	}
+	d := NewDecoder(&inputs)
+
+	e := d.DecodeValues(&inputs, url.Values{"a": {"HTTPS://example.com"}})
+	assertEqual(t, e, ErrorHash(nil))
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"http://example.com"}})
+	assertEqual(t, e, ErrorHash{"a": ErrURLScheme})
+
+	e = d.DecodeValues(&inputs, url.Values{"a": {"/relative"}})
+	assertEqual(t, e, ErrorHash{"a": ErrURLScheme})
+}
+
+func TestURLHosts(t *testing.T) {
+	var inputs struct {
+		A URL `meta_host:"example.com,*.cdn.example.com"`
+		B URL `meta_blocked_host:"metadata.google.internal,*.internal"`
+	}
+	d := NewDecoder(&inputs)
+
+	for _, ok := range []string{"https://example.com", "https://EXAMPLE.com:8443/x", "https://a.cdn.example.com"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {ok}})
+		assertEqual(t, e, ErrorHash(nil), ok)
+	}
+
+	for _, bad := range []string{"https://evil.com", "https://cdn.example.com", "https://example.com.evil.com"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrURLHost}, bad)
+	}
+
+	e := d.DecodeValues(&inputs, url.Values{"b": {"http://metadata.google.internal/computeMetadata"}})
+	assertEqual(t, e, ErrorHash{"b": ErrURLHost})
+
+	e = d.DecodeValues(&inputs, url.Values{"b": {"http://foo.internal"}})
+	assertEqual(t, e, ErrorHash{"b": ErrURLHost})
+
+	e = d.DecodeValues(&inputs, url.Values{"b": {"http://example.com"}})
+	assertEqual(t, e, ErrorHash(nil))
+}
+
+func TestURLDisallowPrivate(t *testing.T) {
+	var inputs struct {
+		A URL `meta_disallow_private:"true"`
+	}
+	d := NewDecoder(&inputs)
+
+	for _, bad := range []string{
+		"http://localhost:8080",
+		"http://api.localhost",
+		"http://127.0.0.1/",
+		"http://10.1.2.3",
+		"http://192.168.0.10",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]:80",
+		"http://[fd00::1]",
+		"http://[::ffff:10.0.0.1]",
+		"http://0.0.0.0",
+		"http://2130706433/",
+		"http://0x7f.0.0.1/",
+		"http://0177.0.0.1/",
+		"http://127.1/",
+		"http://0x7f000001/",
+		"http://8.8.8.010/",
+		"http://localhost./",
+		"http://api.localhost../",
+		"http://127.0.0.1./",
+	} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {bad}})
+		assertEqual(t, e, ErrorHash{"a": ErrPrivateAddress}, bad)
+	}
+
+	for _, ok := range []string{"https://example.com", "http://8.8.8.8", "http://8.8.8.8./", "http://[2001:4860:4860::8888]", "http://1password.com", "http://123.example.com", "/relative"} {
+		e := d.DecodeValues(&inputs, url.Values{"a": {ok}})
+		assertEqual(t, e, ErrorHash(nil), ok)
+	}
+}
+
+func TestURLNull(t *testing.T) {
+	var inputs struct {
+		A URL `meta_null:"true"`
+	}
+
+	e := NewDecoder(&inputs).DecodeJSON(&inputs, []byte(`{"a":null}`))
+	assertEqual(t, e, ErrorHash(nil))
+	assertEqual(t, inputs.A.Present, true)
+	assertEqual(t, inputs.A.Null, true)
+
+	v, err := inputs.A.Value()
+	assertEqual(t, v, nil)
+	assertEqual(t, err, nil)
+}
+
+func TestURLJSON(t *testing.T) {
+	parsed, _ := url.Parse("https://example.com/a?b=c")
+	u := NewURL(parsed)
+
+	v, err := u.Value()
+	assertEqual(t, err, nil)
+	assertEqual(t, v, "https://example.com/a?b=c")
+
+	bs, err := json.Marshal(u)
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), `"https://example.com/a?b=c"`)
+
+	var decoded URL
+	err = json.Unmarshal(bs, &decoded)
+	assertEqual(t, err, nil)
+	assertEqual(t, decoded.Present, true)
+	assertEqual(t, decoded.Val.String(), "https://example.com/a?b=c")
+
+	bs, err = json.Marshal(URL{})
+	assertEqual(t, err, nil)
+	assertEqual(t, string(bs), "null")
+}
@@ -1,5 +1,7 @@
 package meta
 
+import "strings"
+
 var NameMapping = camelCaseToSnakeCase
 
 func camelCaseToSnakeCase(name string) string {
@@ -20,3 +22,27 @@ func camelCaseToSnakeCase(name string) string {
 
 	return string(newstr)
 }
+
+// parseLowerList splits a comma-separated tag value into trimmed, lower-cased, non-empty items.
+func parseLowerList(tagValue string) []string {
+	if tagValue == "" {
+		return nil
+	}
+
+	var out []string
+	for _, s := range strings.Split(tagValue, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}